@@ -0,0 +1,213 @@
+// AggregateTreeMap -- TreeMap with aggregates of user-defined functions over ranges of entries
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+import (
+	"iter"
+)
+
+// Value of an entry in an AggregateTreeMap together with the aggregate
+// of the subtree of the entry's node
+type aggregateValue[V, A any] struct {
+	value V
+	aggr  A
+}
+
+// Key/value map that maintains an aggregate of the entries of each subtree,
+// which allows computing the aggregate of any range of entries in O(log n) time
+//
+// The lift function maps an entry to an aggregate, and the combine function
+// combines the aggregates of two adjacent ranges of entries. The combine
+// function must be associative, but it does not need to be commutative,
+// because aggregates are always combined in ascending key order.
+// Examples are sums, minimums and maximums of the values.
+type AggregateTreeMap[K, V, A any] struct {
+	tree      *TreeMap[K, aggregateValue[V, A]]
+	liftFn    func(key K, value V) A
+	combineFn func(aggr1st, aggr2nd A) A
+}
+
+// Creates an AggregateTreeMap with keys, values and aggregates of type interface{}
+func NewAggregateTreeMap(
+	cmpFn compareFn[interface{}],
+	liftFn func(key, value interface{}) interface{},
+	combineFn func(aggr1st, aggr2nd interface{}) interface{},
+) *AggregateTreeMap[interface{}, interface{}, interface{}] {
+	return NewAggregateTreeMapOf[interface{}, interface{}, interface{}](cmpFn, liftFn, combineFn)
+}
+
+// Creates an AggregateTreeMap with keys of type K, values of type V and
+// aggregates of type A
+func NewAggregateTreeMapOf[K, V, A any](
+	cmpFn compareFn[K],
+	liftFn func(key K, value V) A,
+	combineFn func(aggr1st, aggr2nd A) A,
+) *AggregateTreeMap[K, V, A] {
+	aggrMap := &AggregateTreeMap[K, V, A]{NewTreeMapOf[K, aggregateValue[V, A]](cmpFn), liftFn, combineFn}
+	aggrMap.tree.augmentFn = aggrMap.updateAggregate
+	return aggrMap
+}
+
+// Recomputes the aggregate of a node's subtree from the node's entry and
+// the aggregates of its subtrees
+func (aggrMap *AggregateTreeMap[K, V, A]) updateAggregate(node *treeNode[K, aggregateValue[V, A]]) {
+	aggr := aggrMap.liftFn(node.key, node.value.value)
+	if node.less != nil {
+		aggr = aggrMap.combineFn(node.less.value.aggr, aggr)
+	}
+	if node.greater != nil {
+		aggr = aggrMap.combineFn(aggr, node.greater.value.aggr)
+	}
+	node.value.aggr = aggr
+}
+
+// Inserts or updates the entry with the specified key
+//
+// Returns the previous value and a flag that indicates whether the key was new
+func (aggrMap *AggregateTreeMap[K, V, A]) Insert(key K, value V) (V, bool) {
+	var aggr A
+	prevValue, isNew := aggrMap.tree.Insert(key, aggregateValue[V, A]{value, aggr})
+	return prevValue.value, isNew
+}
+
+func (aggrMap *AggregateTreeMap[K, V, A]) Remove(key K) {
+	aggrMap.tree.Remove(key)
+}
+
+func (aggrMap *AggregateTreeMap[K, V, A]) Get(key K) (V, bool) {
+	value, flag := aggrMap.tree.Get(key)
+	return value.value, flag
+}
+
+func (aggrMap *AggregateTreeMap[K, V, A]) GetSize() int {
+	return aggrMap.tree.GetSize()
+}
+
+// Returns a sequence of all entries in ascending key order
+func (aggrMap *AggregateTreeMap[K, V, A]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, value := range aggrMap.tree.All() {
+			if !yield(key, value.value) {
+				break
+			}
+		}
+	}
+}
+
+// Returns the aggregate of all entries and a flag that indicates whether
+// the map contains any entries
+func (aggrMap *AggregateTreeMap[K, V, A]) Aggregate() (A, bool) {
+	var aggr A
+	flag := aggrMap.tree.root != nil
+	if flag {
+		aggr = aggrMap.tree.root.value.aggr
+	}
+	return aggr, flag
+}
+
+// Returns the aggregate of the entries with keys within the specified bounds
+// and a flag that indicates whether the range contains any entries
+func (aggrMap *AggregateTreeMap[K, V, A]) RangeReduce(lower, upper Bound[K]) (A, bool) {
+	var aggr A
+	flag := false
+	cmpFn := aggrMap.tree.cmpFn
+	node := aggrMap.tree.root
+	for node != nil {
+		if !lower.admitsAbove(node.key, cmpFn) {
+			node = node.greater
+		} else if !upper.admitsBelow(node.key, cmpFn) {
+			node = node.less
+		} else {
+			// The paths to both bounds split at this node
+			lessAggr, lessFlag := aggrMap.reduceAbove(node.less, lower)
+			greaterAggr, greaterFlag := aggrMap.reduceBelow(node.greater, upper)
+			aggr = aggrMap.liftFn(node.key, node.value.value)
+			if lessFlag {
+				aggr = aggrMap.combineFn(lessAggr, aggr)
+			}
+			if greaterFlag {
+				aggr = aggrMap.combineFn(aggr, greaterAggr)
+			}
+			flag = true
+			break
+		}
+	}
+	return aggr, flag
+}
+
+// Returns the aggregate of the entries of a subtree with keys within the lower bound
+func (aggrMap *AggregateTreeMap[K, V, A]) reduceAbove(node *treeNode[K, aggregateValue[V, A]], lower Bound[K]) (A, bool) {
+	var aggr A
+	flag := false
+	for node != nil {
+		if lower.admitsAbove(node.key, aggrMap.tree.cmpFn) {
+			// The node and its greater subtree precede the entries collected so far
+			nodeAggr := aggrMap.liftFn(node.key, node.value.value)
+			if node.greater != nil {
+				nodeAggr = aggrMap.combineFn(nodeAggr, node.greater.value.aggr)
+			}
+			if flag {
+				aggr = aggrMap.combineFn(nodeAggr, aggr)
+			} else {
+				aggr = nodeAggr
+				flag = true
+			}
+			node = node.less
+		} else {
+			node = node.greater
+		}
+	}
+	return aggr, flag
+}
+
+// Returns the aggregate of the entries of a subtree with keys within the upper bound
+func (aggrMap *AggregateTreeMap[K, V, A]) reduceBelow(node *treeNode[K, aggregateValue[V, A]], upper Bound[K]) (A, bool) {
+	var aggr A
+	flag := false
+	for node != nil {
+		if upper.admitsBelow(node.key, aggrMap.tree.cmpFn) {
+			// The less subtree and the node follow the entries collected so far
+			nodeAggr := aggrMap.liftFn(node.key, node.value.value)
+			if node.less != nil {
+				nodeAggr = aggrMap.combineFn(node.less.value.aggr, nodeAggr)
+			}
+			if flag {
+				aggr = aggrMap.combineFn(aggr, nodeAggr)
+			} else {
+				aggr = nodeAggr
+				flag = true
+			}
+			node = node.greater
+		} else {
+			node = node.less
+		}
+	}
+	return aggr, flag
+}
@@ -0,0 +1,109 @@
+// AggregateTreeMap tests -- range aggregates checked against sums over a Go map
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+import (
+	"cmp"
+	"math/rand"
+	"testing"
+)
+
+func TestAggregateTreeMapRangeReduce(t *testing.T) {
+	tests := []struct {
+		name     string
+		keyRange int
+		opCount  int
+	}{
+		{"dense", 100, 2000},
+		{"sparse", 10000, 2000},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			random := rand.New(rand.NewSource(6))
+			aggrMap := NewAggregateTreeMapOf[int, int, int](
+				cmp.Compare[int],
+				func(key, value int) int { return value },
+				func(aggr1st, aggr2nd int) int { return aggr1st + aggr2nd },
+			)
+			ref := make(map[int]int)
+			for idx := 0; idx < test.opCount; idx++ {
+				key := random.Intn(test.keyRange)
+				if random.Intn(3) == 0 {
+					aggrMap.Remove(key)
+					delete(ref, key)
+				} else {
+					aggrMap.Insert(key, idx)
+					ref[key] = idx
+				}
+				if err := aggrMap.tree.Validate(); err != nil {
+					t.Fatal(err)
+				}
+
+				lower := randomBound(random, test.keyRange)
+				upper := randomBound(random, test.keyRange)
+				expected := 0
+				expectedFlag := false
+				for key, value := range ref {
+					if lower.admitsAbove(key, cmp.Compare[int]) && upper.admitsBelow(key, cmp.Compare[int]) {
+						expected += value
+						expectedFlag = true
+					}
+				}
+				if aggr, flag := aggrMap.RangeReduce(lower, upper); aggr != expected || flag != expectedFlag {
+					t.Fatalf("range reduce of %v, %v returned %d, %t, expected %d, %t",
+						lower, upper, aggr, flag, expected, expectedFlag)
+				}
+			}
+			total := 0
+			for _, value := range ref {
+				total += value
+			}
+			if aggr, flag := aggrMap.Aggregate(); aggr != total || flag != (len(ref) > 0) {
+				t.Fatalf("aggregate is %d, %t, expected %d", aggr, flag, total)
+			}
+		})
+	}
+}
+
+// Returns an inclusive, exclusive or unbounded bound with a key that may be
+// slightly outside of the key range
+func randomBound(random *rand.Rand, keyRange int) Bound[int] {
+	key := random.Intn(keyRange+20) - 10
+	var bound Bound[int]
+	switch random.Intn(5) {
+	case 0:
+		bound = Unbounded[int]()
+	case 1, 2:
+		bound = Inclusive(key)
+	default:
+		bound = Exclusive(key)
+	}
+	return bound
+}
@@ -0,0 +1,177 @@
+// Codecs -- binary encoders and decoders for the key and value types of maps
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// Error that is reported if encoded data cannot be decoded
+var ErrInvalidEncoding = errors.New("dsaext: invalid binary encoding")
+
+// Binary encoder and decoder for values of type T
+type Codec[T any] interface {
+	// Appends the encoding of the value to the buffer and returns the extended buffer
+	Append(buffer []byte, value T) []byte
+	// Decodes a value from the start of the data and returns the value and
+	// the number of bytes that were decoded
+	Decode(data []byte) (T, int, error)
+}
+
+// Codecs for the types supported by the standard comparison functions
+var (
+	IntCodec     Codec[int]     = signedCodec[int]{}
+	Int8Codec    Codec[int8]    = signedCodec[int8]{}
+	Int16Codec   Codec[int16]   = signedCodec[int16]{}
+	Int32Codec   Codec[int32]   = signedCodec[int32]{}
+	Int64Codec   Codec[int64]   = signedCodec[int64]{}
+	UInt8Codec   Codec[uint8]   = unsignedCodec[uint8]{}
+	UInt16Codec  Codec[uint16]  = unsignedCodec[uint16]{}
+	UInt32Codec  Codec[uint32]  = unsignedCodec[uint32]{}
+	UInt64Codec  Codec[uint64]  = unsignedCodec[uint64]{}
+	Float32Codec Codec[float32] = float32Codec{}
+	Float64Codec Codec[float64] = float64Codec{}
+	StringCodec  Codec[string]  = stringCodec{}
+)
+
+// Variable length zig-zag encoding of signed integers
+type signedCodec[T ~int | ~int8 | ~int16 | ~int32 | ~int64] struct{}
+
+func (signedCodec[T]) Append(buffer []byte, value T) []byte {
+	return binary.AppendVarint(buffer, int64(value))
+}
+
+func (signedCodec[T]) Decode(data []byte) (T, int, error) {
+	var err error = nil
+	number, length := binary.Varint(data)
+	value := T(number)
+	if length <= 0 || int64(value) != number {
+		err = ErrInvalidEncoding
+	}
+	return value, length, err
+}
+
+// Variable length encoding of unsigned integers
+type unsignedCodec[T ~uint8 | ~uint16 | ~uint32 | ~uint64] struct{}
+
+func (unsignedCodec[T]) Append(buffer []byte, value T) []byte {
+	return binary.AppendUvarint(buffer, uint64(value))
+}
+
+func (unsignedCodec[T]) Decode(data []byte) (T, int, error) {
+	var err error = nil
+	number, length := binary.Uvarint(data)
+	value := T(number)
+	if length <= 0 || uint64(value) != number {
+		err = ErrInvalidEncoding
+	}
+	return value, length, err
+}
+
+// Little endian IEEE 754 encoding of single precision floating point numbers
+type float32Codec struct{}
+
+func (float32Codec) Append(buffer []byte, value float32) []byte {
+	return binary.LittleEndian.AppendUint32(buffer, math.Float32bits(value))
+}
+
+func (float32Codec) Decode(data []byte) (float32, int, error) {
+	var value float32 = 0
+	var err error = nil
+	if len(data) >= 4 {
+		value = math.Float32frombits(binary.LittleEndian.Uint32(data))
+	} else {
+		err = ErrInvalidEncoding
+	}
+	return value, 4, err
+}
+
+// Little endian IEEE 754 encoding of double precision floating point numbers
+type float64Codec struct{}
+
+func (float64Codec) Append(buffer []byte, value float64) []byte {
+	return binary.LittleEndian.AppendUint64(buffer, math.Float64bits(value))
+}
+
+func (float64Codec) Decode(data []byte) (float64, int, error) {
+	var value float64 = 0
+	var err error = nil
+	if len(data) >= 8 {
+		value = math.Float64frombits(binary.LittleEndian.Uint64(data))
+	} else {
+		err = ErrInvalidEncoding
+	}
+	return value, 8, err
+}
+
+// Length-prefixed encoding of strings
+type stringCodec struct{}
+
+func (stringCodec) Append(buffer []byte, value string) []byte {
+	buffer = binary.AppendUvarint(buffer, uint64(len(value)))
+	return append(buffer, value...)
+}
+
+func (stringCodec) Decode(data []byte) (string, int, error) {
+	var value string = ""
+	var err error = nil
+	textLength, length := binary.Uvarint(data)
+	if length > 0 && textLength <= uint64(len(data)-length) {
+		value = string(data[length : length+int(textLength)])
+		length += int(textLength)
+	} else {
+		err = ErrInvalidEncoding
+	}
+	return value, length, err
+}
+
+// Creates a codec for interface{} values that holds values of type T from
+// a codec for values of type T
+//
+// Encoding a value of a different type causes a panic, like comparing it with
+// one of the standard comparison functions.
+func InterfaceCodec[T any](codec Codec[T]) Codec[interface{}] {
+	return interfaceCodec[T]{codec}
+}
+
+type interfaceCodec[T any] struct {
+	codec Codec[T]
+}
+
+func (ifCodec interfaceCodec[T]) Append(buffer []byte, value interface{}) []byte {
+	return ifCodec.codec.Append(buffer, value.(T))
+}
+
+func (ifCodec interfaceCodec[T]) Decode(data []byte) (interface{}, int, error) {
+	value, length, err := ifCodec.codec.Decode(data)
+	return value, length, err
+}
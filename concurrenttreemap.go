@@ -0,0 +1,200 @@
+// ConcurrentTreeMap -- concurrency-safe wrapper for a TreeMap
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+import (
+	"iter"
+	"sync"
+)
+
+// TreeMap that can be used by multiple goroutines concurrently
+//
+// Iterators and sequences operate on a snapshot of the entries that is taken
+// when the iterator is created or when the sequence is ranged over, and are
+// not affected by later modifications of the map.
+type ConcurrentTreeMap[K, V any] struct {
+	lock sync.RWMutex
+	tree *TreeMap[K, V]
+}
+
+// Creates a ConcurrentTreeMap with keys and values of type interface{}
+func NewConcurrentTreeMap(cmpFn compareFn[interface{}]) *ConcurrentTreeMap[interface{}, interface{}] {
+	return NewConcurrentTreeMapOf[interface{}, interface{}](cmpFn)
+}
+
+// Creates a ConcurrentTreeMap with keys of type K and values of type V
+func NewConcurrentTreeMapOf[K, V any](cmpFn compareFn[K]) *ConcurrentTreeMap[K, V] {
+	return &ConcurrentTreeMap[K, V]{tree: NewTreeMapOf[K, V](cmpFn)}
+}
+
+func (cmap *ConcurrentTreeMap[K, V]) Insert(key K, value V) (V, bool) {
+	cmap.lock.Lock()
+	defer cmap.lock.Unlock()
+	return cmap.tree.Insert(key, value)
+}
+
+func (cmap *ConcurrentTreeMap[K, V]) Remove(key K) {
+	cmap.lock.Lock()
+	defer cmap.lock.Unlock()
+	cmap.tree.Remove(key)
+}
+
+func (cmap *ConcurrentTreeMap[K, V]) Get(key K) (V, bool) {
+	cmap.lock.RLock()
+	defer cmap.lock.RUnlock()
+	return cmap.tree.Get(key)
+}
+
+// Returns the value of the entry with the specified key if the map contains
+// such an entry, otherwise inserts the specified value
+//
+// The flag that is returned indicates whether the entry was already present.
+func (cmap *ConcurrentTreeMap[K, V]) GetOrInsert(key K, value V) (V, bool) {
+	cmap.lock.Lock()
+	defer cmap.lock.Unlock()
+	retValue, inserted := cmap.tree.PutIfAbsent(key, value)
+	return retValue, !inserted
+}
+
+func (cmap *ConcurrentTreeMap[K, V]) Replace(key K, value V) (V, bool) {
+	cmap.lock.Lock()
+	defer cmap.lock.Unlock()
+	return cmap.tree.Replace(key, value)
+}
+
+// Atomically computes the new value for a key, see TreeMap.Compute
+//
+// The compute function must not access the map.
+func (cmap *ConcurrentTreeMap[K, V]) Compute(key K, computeFn func(oldValue V, exists bool) (V, bool)) (V, bool) {
+	cmap.lock.Lock()
+	defer cmap.lock.Unlock()
+	return cmap.tree.Compute(key, computeFn)
+}
+
+// Atomically inserts or merges a value, see TreeMap.Merge
+//
+// The merge function must not access the map.
+func (cmap *ConcurrentTreeMap[K, V]) Merge(key K, value V, mergeFn func(oldValue, value V) V) V {
+	cmap.lock.Lock()
+	defer cmap.lock.Unlock()
+	return cmap.tree.Merge(key, value, mergeFn)
+}
+
+func (cmap *ConcurrentTreeMap[K, V]) GetFirstKey() (K, bool) {
+	cmap.lock.RLock()
+	defer cmap.lock.RUnlock()
+	return cmap.tree.GetFirstKey()
+}
+
+func (cmap *ConcurrentTreeMap[K, V]) GetLastKey() (K, bool) {
+	cmap.lock.RLock()
+	defer cmap.lock.RUnlock()
+	return cmap.tree.GetLastKey()
+}
+
+func (cmap *ConcurrentTreeMap[K, V]) Floor(key K) (K, V, bool) {
+	cmap.lock.RLock()
+	defer cmap.lock.RUnlock()
+	return cmap.tree.Floor(key)
+}
+
+func (cmap *ConcurrentTreeMap[K, V]) Ceiling(key K) (K, V, bool) {
+	cmap.lock.RLock()
+	defer cmap.lock.RUnlock()
+	return cmap.tree.Ceiling(key)
+}
+
+func (cmap *ConcurrentTreeMap[K, V]) Lower(key K) (K, V, bool) {
+	cmap.lock.RLock()
+	defer cmap.lock.RUnlock()
+	return cmap.tree.Lower(key)
+}
+
+func (cmap *ConcurrentTreeMap[K, V]) Higher(key K) (K, V, bool) {
+	cmap.lock.RLock()
+	defer cmap.lock.RUnlock()
+	return cmap.tree.Higher(key)
+}
+
+func (cmap *ConcurrentTreeMap[K, V]) GetSize() int {
+	cmap.lock.RLock()
+	defer cmap.lock.RUnlock()
+	return cmap.tree.GetSize()
+}
+
+// Runs a function that may read the map while holding the read lock
+//
+// The function must not modify the map and must not retain the TreeMap
+// or any of its iterators after returning.
+func (cmap *ConcurrentTreeMap[K, V]) View(viewFn func(tree *TreeMap[K, V])) {
+	cmap.lock.RLock()
+	defer cmap.lock.RUnlock()
+	viewFn(cmap.tree)
+}
+
+// Runs a function that may read and modify the map while holding the write lock,
+// which makes any sequence of operations on the map atomic
+//
+// The function must not retain the TreeMap or any of its iterators after returning.
+func (cmap *ConcurrentTreeMap[K, V]) Update(updateFn func(tree *TreeMap[K, V])) {
+	cmap.lock.Lock()
+	defer cmap.lock.Unlock()
+	updateFn(cmap.tree)
+}
+
+// Returns a copy of all entries in ascending key order
+func (cmap *ConcurrentTreeMap[K, V]) Snapshot() []Entry[K, V] {
+	cmap.lock.RLock()
+	defer cmap.lock.RUnlock()
+	entries := make([]Entry[K, V], 0, cmap.tree.GetSize())
+	for key, value := range cmap.tree.All() {
+		entries = append(entries, Entry[K, V]{key, value})
+	}
+	return entries
+}
+
+// Returns an iterator over a snapshot of the entries in ascending key order
+func (cmap *ConcurrentTreeMap[K, V]) Iterator() *SnapshotIterator[K, V] {
+	return &SnapshotIterator[K, V]{cmap.Snapshot(), 0}
+}
+
+// Returns a sequence of the entries in ascending key order
+//
+// Each time the sequence is ranged over, it iterates over a new snapshot of the
+// entries, so the loop body may modify the map.
+func (cmap *ConcurrentTreeMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, entry := range cmap.Snapshot() {
+			if !yield(entry.Key, entry.Value) {
+				break
+			}
+		}
+	}
+}
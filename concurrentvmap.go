@@ -0,0 +1,168 @@
+// ConcurrentVMap -- concurrency-safe wrapper for a VMap
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+import (
+	"iter"
+	"sync"
+)
+
+// VMap that can be used by multiple goroutines concurrently
+//
+// Iterators and sequences operate on a snapshot of the entries that is taken
+// when the iterator is created or when the sequence is ranged over, and are
+// not affected by later modifications of the map.
+type ConcurrentVMap[K, V any] struct {
+	lock   sync.RWMutex
+	mapObj *VMap[K, V]
+}
+
+// Creates a ConcurrentVMap with keys and values of type interface{}
+func NewConcurrentVMap(cmpFn compareFn[interface{}]) *ConcurrentVMap[interface{}, interface{}] {
+	return NewConcurrentVMapOf[interface{}, interface{}](cmpFn)
+}
+
+// Creates a ConcurrentVMap with keys of type K and values of type V
+func NewConcurrentVMapOf[K, V any](cmpFn compareFn[K]) *ConcurrentVMap[K, V] {
+	return &ConcurrentVMap[K, V]{mapObj: NewVMapOf[K, V](cmpFn)}
+}
+
+func (cmap *ConcurrentVMap[K, V]) Clear() {
+	cmap.lock.Lock()
+	defer cmap.lock.Unlock()
+	cmap.mapObj.Clear()
+}
+
+func (cmap *ConcurrentVMap[K, V]) GetSize() int {
+	cmap.lock.RLock()
+	defer cmap.lock.RUnlock()
+	return cmap.mapObj.GetSize()
+}
+
+func (cmap *ConcurrentVMap[K, V]) Prepend(key K, value V) {
+	cmap.lock.Lock()
+	defer cmap.lock.Unlock()
+	cmap.mapObj.Prepend(key, value)
+}
+
+func (cmap *ConcurrentVMap[K, V]) Append(key K, value V) {
+	cmap.lock.Lock()
+	defer cmap.lock.Unlock()
+	cmap.mapObj.Append(key, value)
+}
+
+// Returns the value of the entry with the specified key if the map contains
+// such an entry, otherwise appends an entry with the specified value
+//
+// The flag that is returned indicates whether the entry was already present.
+func (cmap *ConcurrentVMap[K, V]) GetOrAppend(key K, value V) (V, bool) {
+	cmap.lock.Lock()
+	defer cmap.lock.Unlock()
+	retValue, retFlag := cmap.mapObj.Get(key)
+	if !retFlag {
+		cmap.mapObj.Append(key, value)
+		retValue = value
+	}
+	return retValue, retFlag
+}
+
+func (cmap *ConcurrentVMap[K, V]) Get(key K) (V, bool) {
+	cmap.lock.RLock()
+	defer cmap.lock.RUnlock()
+	return cmap.mapObj.Get(key)
+}
+
+func (cmap *ConcurrentVMap[K, V]) GetFirst() (K, V, bool) {
+	cmap.lock.RLock()
+	defer cmap.lock.RUnlock()
+	return cmap.mapObj.GetFirst()
+}
+
+func (cmap *ConcurrentVMap[K, V]) GetLast() (K, V, bool) {
+	cmap.lock.RLock()
+	defer cmap.lock.RUnlock()
+	return cmap.mapObj.GetLast()
+}
+
+func (cmap *ConcurrentVMap[K, V]) Remove(key K) {
+	cmap.lock.Lock()
+	defer cmap.lock.Unlock()
+	cmap.mapObj.Remove(key)
+}
+
+// Runs a function that may read the map while holding the read lock
+//
+// The function must not modify the map and must not retain the VMap
+// or any of its iterators after returning.
+func (cmap *ConcurrentVMap[K, V]) View(viewFn func(mapObj *VMap[K, V])) {
+	cmap.lock.RLock()
+	defer cmap.lock.RUnlock()
+	viewFn(cmap.mapObj)
+}
+
+// Runs a function that may read and modify the map while holding the write lock,
+// which makes any sequence of operations on the map atomic
+//
+// The function must not retain the VMap or any of its iterators after returning.
+func (cmap *ConcurrentVMap[K, V]) Update(updateFn func(mapObj *VMap[K, V])) {
+	cmap.lock.Lock()
+	defer cmap.lock.Unlock()
+	updateFn(cmap.mapObj)
+}
+
+// Returns a copy of all entries in the order of the map
+func (cmap *ConcurrentVMap[K, V]) Snapshot() []Entry[K, V] {
+	cmap.lock.RLock()
+	defer cmap.lock.RUnlock()
+	entries := make([]Entry[K, V], 0, cmap.mapObj.GetSize())
+	for key, value := range cmap.mapObj.All() {
+		entries = append(entries, Entry[K, V]{key, value})
+	}
+	return entries
+}
+
+// Returns an iterator over a snapshot of the entries in the order of the map
+func (cmap *ConcurrentVMap[K, V]) Iterator() *SnapshotIterator[K, V] {
+	return &SnapshotIterator[K, V]{cmap.Snapshot(), 0}
+}
+
+// Returns a sequence of the entries in the order of the map
+//
+// Each time the sequence is ranged over, it iterates over a new snapshot of the
+// entries, so the loop body may modify the map.
+func (cmap *ConcurrentVMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, entry := range cmap.Snapshot() {
+			if !yield(entry.Key, entry.Value) {
+				break
+			}
+		}
+	}
+}
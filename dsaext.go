@@ -37,4 +37,96 @@ package dsaext
 //     < 0    if value1st is less than value2nd
 //     > 0    if value1st is greater than value2nd
 //     0      if value1st and value2nd have equal value
-type compareFn func(value1st, value2nd interface{}) int
+//
+// The standard comparison functions in stdcompare.go compare keys of type
+// interface{} and are suitable for compareFn[interface{}]
+type compareFn[K any] func(value1st, value2nd K) int
+
+type boundKind int
+
+const (
+	unboundedKind boundKind = iota
+	inclusiveKind
+	exclusiveKind
+)
+
+// Lower or upper bound of a range of keys
+//
+// The zero value of a Bound is unbounded
+type Bound[K any] struct {
+	kind boundKind
+	key  K
+}
+
+// Creates a bound that includes the specified key in the range
+func Inclusive[K any](key K) Bound[K] {
+	return Bound[K]{inclusiveKind, key}
+}
+
+// Creates a bound that excludes the specified key from the range
+func Exclusive[K any](key K) Bound[K] {
+	return Bound[K]{exclusiveKind, key}
+}
+
+// Creates a bound that does not limit the range
+func Unbounded[K any]() Bound[K] {
+	return Bound[K]{}
+}
+
+// Indicates whether a key is not greater than the bound if used as an upper bound
+func (bound Bound[K]) admitsBelow(key K, cmpFn compareFn[K]) bool {
+	result := true
+	if bound.kind != unboundedKind {
+		dir := cmpFn(key, bound.key)
+		result = dir < 0 || (dir == 0 && bound.kind == inclusiveKind)
+	}
+	return result
+}
+
+// Indicates whether a key is not less than the bound if used as a lower bound
+func (bound Bound[K]) admitsAbove(key K, cmpFn compareFn[K]) bool {
+	result := true
+	if bound.kind != unboundedKind {
+		dir := cmpFn(key, bound.key)
+		result = dir > 0 || (dir == 0 && bound.kind == inclusiveKind)
+	}
+	return result
+}
+
+// Settings of a map that are not part of its entries
+//
+// Maps that are derived from a map, e.g. by SplitAt or JoinTreeMaps, copy the
+// settings as a whole.
+type mapSettings[K, V any] struct {
+	// Key and value codecs for the binary encoding, nil if not set
+	codecs *mapCodecs[K, V]
+	// Set if the JSON encoding is an array of key/value pairs instead of an object
+	jsonPairs bool
+	// Conversion of keys decoded from JSON, nil if keys are used as decoded
+	jsonKeyFn jsonKeyFn[K]
+}
+
+// Key/value pair
+type Entry[K, V any] struct {
+	Key   K
+	Value V
+}
+
+// Iterator over a copy of the entries of a map
+type SnapshotIterator[K, V any] struct {
+	entries []Entry[K, V]
+	index   int
+}
+
+func (iter *SnapshotIterator[K, V]) Next() (K, V, bool) {
+	var key K
+	var value V
+	flag := false
+	if iter.index < len(iter.entries) {
+		key = iter.entries[iter.index].Key
+		value = iter.entries[iter.index].Value
+		flag = true
+		iter.index++
+	}
+	return key, value, flag
+}
@@ -0,0 +1,372 @@
+// IntervalMap -- interval tree implementation of a map of intervals to values
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+import (
+	"errors"
+	"iter"
+	"math"
+)
+
+// Error that is reported if an interval's end is not greater than its start
+var ErrEmptyInterval = errors.New("dsaext: interval end is not greater than its start")
+
+// Half-open interval [Start, End)
+type Interval[K any] struct {
+	Start K
+	End   K
+}
+
+// Interval and value of an entry in an IntervalMap
+type intervalEntry[K, V any] struct {
+	key   multiKey[K]
+	end   K
+	value V
+	// Set if the entry is held in the slot of a node
+	held bool
+}
+
+// Value of a node of an IntervalMap
+//
+// The slot belongs to the node's position in the tree, not to its entry, but
+// it is stored with the entry, because the tree moves entries between nodes
+// when removing entries. All nodes whose slots may have moved are marked as
+// dirty, and their slots are reassigned after each modification.
+type intervalValue[K, V any] struct {
+	entry *intervalEntry[K, V]
+	// Entry with the greatest end among the entries in the node's subtree that
+	// are not held in the slot of an ancestor node, nil if there is no such entry
+	slot  *intervalEntry[K, V]
+	dirty bool
+}
+
+type intervalNode[K, V any] = treeNode[multiKey[K], intervalValue[K, V]]
+
+// Map of half-open intervals to values
+//
+// Intervals are ordered by their start, and any number of intervals may
+// overlap or be equal.
+//
+// The nodes of the tree also form a priority search tree over the intervals:
+// each node has a slot that holds the interval with the greatest end among the
+// intervals in its subtree that are not held by an ancestor node. Intervals
+// that are not held by any slot have an end that is not greater than the end
+// of the interval in their own node's slot. Stabbing and overlap queries that
+// yield k intervals therefore take O(log n + k) time. Insert and Remove take
+// O(log² n) time, because the slots of the nodes that were changed by
+// rebalancing are reassigned.
+type IntervalMap[K, V any] struct {
+	tree    *TreeMap[multiKey[K], intervalValue[K, V]]
+	cmpFn   compareFn[K]
+	nextSeq uint64
+	// Nodes that were changed by the last modification of the tree
+	dirty []*intervalNode[K, V]
+}
+
+// Creates an IntervalMap with interval bounds and values of type interface{}
+func NewIntervalMap(cmpFn compareFn[interface{}]) *IntervalMap[interface{}, interface{}] {
+	return NewIntervalMapOf[interface{}, interface{}](cmpFn)
+}
+
+// Creates an IntervalMap with interval bounds of type K and values of type V
+func NewIntervalMapOf[K, V any](cmpFn compareFn[K]) *IntervalMap[K, V] {
+	intervalMap := &IntervalMap[K, V]{NewTreeMapOf[multiKey[K], intervalValue[K, V]](multiKeyCompareFn(cmpFn)), cmpFn, 0, nil}
+	intervalMap.tree.augmentFn = intervalMap.markDirty
+	return intervalMap
+}
+
+// Records a node that was changed by a modification of the tree
+func (intervalMap *IntervalMap[K, V]) markDirty(node *intervalNode[K, V]) {
+	if !node.value.dirty {
+		node.value.dirty = true
+		intervalMap.dirty = append(intervalMap.dirty, node)
+	}
+}
+
+// Adds an interval after all existing intervals with an equal start
+//
+// Returns ErrEmptyInterval if end is not greater than start, because the half-open
+// interval [start, end) would contain no points.
+func (intervalMap *IntervalMap[K, V]) Insert(start, end K, value V) error {
+	var err error = nil
+	if intervalMap.cmpFn(start, end) < 0 {
+		key := multiKey[K]{start, intervalMap.nextSeq}
+		entry := &intervalEntry[K, V]{key, end, value, false}
+		intervalMap.tree.Insert(key, intervalValue[K, V]{entry, nil, false})
+		intervalMap.nextSeq++
+		intervalMap.updateSlots(nil, nil)
+	} else {
+		err = ErrEmptyInterval
+	}
+	return err
+}
+
+// Removes the interval with the specified start and end that was inserted first
+//
+// The flag that is returned indicates whether an interval was removed.
+func (intervalMap *IntervalMap[K, V]) Remove(start, end K) bool {
+	retFlag := false
+	node := intervalMap.tree.lowerBoundNode(keyLowerBound(start))
+	for node != nil && intervalMap.cmpFn(start, node.key.key) == 0 {
+		if intervalMap.cmpFn(end, node.value.entry.end) == 0 {
+			// The node's value, including its slot, is overwritten or dropped
+			// by the removal, therefore the slot's entry is released here
+			removed := node.value.entry
+			released := node.value.slot
+			intervalMap.tree.removeNode(node)
+			intervalMap.updateSlots(released, removed)
+			retFlag = true
+			break
+		}
+		node = node.successor()
+	}
+	return retFlag
+}
+
+func (intervalMap *IntervalMap[K, V]) GetSize() int {
+	return intervalMap.tree.GetSize()
+}
+
+// Returns a sequence of all intervals in ascending order of their start,
+// with intervals with equal starts in insertion order
+func (intervalMap *IntervalMap[K, V]) All() iter.Seq2[Interval[K], V] {
+	return func(yield func(Interval[K], V) bool) {
+		for _, value := range intervalMap.tree.All() {
+			if !yield(value.entry.interval(), value.entry.value) {
+				break
+			}
+		}
+	}
+}
+
+// Returns a sequence of the intervals that contain the specified point
+//
+// The intervals are yielded in no particular order. Yielding k intervals takes
+// O(log n + k) time.
+func (intervalMap *IntervalMap[K, V]) Stab(point K) iter.Seq2[Interval[K], V] {
+	return func(yield func(Interval[K], V) bool) {
+		intervalMap.stabWalk(intervalMap.tree.root, point, yield)
+	}
+}
+
+// Returns a sequence of the intervals that overlap the half-open interval
+// [start, end)
+//
+// The intervals that contain start are yielded first, in no particular order,
+// followed by the intervals that start after start in ascending order of their
+// start. The sequence is empty if end is not greater than start, because an
+// empty interval overlaps no intervals. Yielding k intervals takes O(log n + k)
+// time.
+func (intervalMap *IntervalMap[K, V]) Overlapping(start, end K) iter.Seq2[Interval[K], V] {
+	return func(yield func(Interval[K], V) bool) {
+		if intervalMap.cmpFn(start, end) < 0 && intervalMap.stabWalk(intervalMap.tree.root, start, yield) {
+			// Intervals that start within (start, end) overlap the query interval,
+			// because they are not empty
+			rangeIter := intervalMap.tree.Range(
+				Exclusive(multiKey[K]{start, math.MaxUint64}),
+				Exclusive(multiKey[K]{end, 0}),
+			)
+			for _, value, flag := rangeIter.Next(); flag; _, value, flag = rangeIter.Next() {
+				if !yield(value.entry.interval(), value.entry.value) {
+					break
+				}
+			}
+		}
+	}
+}
+
+func (entry *intervalEntry[K, V]) interval() Interval[K] {
+	return Interval[K]{entry.key.key, entry.end}
+}
+
+// Yields the intervals of a subtree that contain the specified point
+//
+// A subtree is skipped if the entry in its root's slot does not end after the
+// point, because no entry in the subtree that was not yielded by an ancestor
+// node ends later. Greater subtrees of nodes that start after the point are
+// skipped as well. Each visited node either yields an interval, is on the
+// search path for the point, or is a child of such a node.
+// Returns false if the yield function stopped the iteration.
+func (intervalMap *IntervalMap[K, V]) stabWalk(
+	node *intervalNode[K, V],
+	point K,
+	yield func(Interval[K], V) bool,
+) bool {
+	result := true
+	if node != nil && node.value.slot != nil && intervalMap.cmpFn(node.value.slot.end, point) > 0 {
+		slot := node.value.slot
+		if intervalMap.cmpFn(slot.key.key, point) <= 0 {
+			result = yield(slot.interval(), slot.value)
+		}
+		// The node's own entry is only yielded here if no slot holds it
+		own := node.value.entry
+		if result && !own.held && intervalMap.containsPoint(own, point) {
+			result = yield(own.interval(), own.value)
+		}
+		if result {
+			result = intervalMap.stabWalk(node.less, point, yield)
+		}
+		if result && intervalMap.cmpFn(node.key.key, point) <= 0 {
+			result = intervalMap.stabWalk(node.greater, point, yield)
+		}
+	}
+	return result
+}
+
+func (intervalMap *IntervalMap[K, V]) containsPoint(entry *intervalEntry[K, V], point K) bool {
+	return intervalMap.cmpFn(entry.key.key, point) <= 0 && intervalMap.cmpFn(entry.end, point) > 0
+}
+
+// Reassigns the slots of the nodes that were changed by a modification of the tree
+//
+// Released is an entry that was held in the slot of a node that was dropped or
+// overwritten, and removed is the entry that was removed from the tree, both
+// may be nil.
+//
+// The set of changed nodes is extended to include all their ancestors. The
+// slots of all changed nodes are emptied, the released entries that belong to
+// subtrees of unchanged nodes are sifted into those subtrees, and finally the
+// slots of the changed nodes are refilled bottom-up.
+func (intervalMap *IntervalMap[K, V]) updateSlots(released, removed *intervalEntry[K, V]) {
+	for _, node := range intervalMap.dirty {
+		for parent := node.parent; parent != nil && !parent.value.dirty; parent = parent.parent {
+			intervalMap.markDirty(parent)
+		}
+	}
+	releasedEntries := make([]*intervalEntry[K, V], 0, len(intervalMap.dirty)+1)
+	if released != nil {
+		releasedEntries = append(releasedEntries, released)
+	}
+	for _, node := range intervalMap.dirty {
+		if node.value.slot != nil {
+			releasedEntries = append(releasedEntries, node.value.slot)
+			node.value.slot = nil
+		}
+	}
+	for _, entry := range releasedEntries {
+		entry.held = false
+	}
+	for _, entry := range releasedEntries {
+		if entry != removed {
+			intervalMap.siftReleased(entry)
+		}
+	}
+	intervalMap.refillSlots(intervalMap.tree.root)
+	clear(intervalMap.dirty)
+	intervalMap.dirty = intervalMap.dirty[:0]
+}
+
+// Sifts a released entry into the subtree of the topmost unchanged node that
+// contains the entry's node
+//
+// Entries of changed nodes are not sifted, they are candidates for the slots
+// of their own nodes when the slots are refilled.
+func (intervalMap *IntervalMap[K, V]) siftReleased(entry *intervalEntry[K, V]) {
+	node := intervalMap.tree.root
+	for node != nil && node.value.dirty && node.value.entry != entry {
+		if intervalMap.tree.cmpFn(entry.key, node.key) < 0 {
+			node = node.less
+		} else {
+			node = node.greater
+		}
+	}
+	if node != nil && !node.value.dirty {
+		intervalMap.siftDown(node, entry)
+	}
+}
+
+// Adds an entry of a node's subtree that is not held by any slot to the slots
+// of the subtree
+//
+// The entry takes the first slot on the path to its own node that is empty or
+// holds an entry with a lower end. A displaced entry continues on the path to
+// its own node. An entry that reaches its own node without taking a slot
+// remains unheld.
+func (intervalMap *IntervalMap[K, V]) siftDown(node *intervalNode[K, V], entry *intervalEntry[K, V]) {
+	for entry != nil {
+		slot := node.value.slot
+		if slot == nil || intervalMap.cmpFn(entry.end, slot.end) > 0 {
+			node.value.slot = entry
+			entry.held = true
+			if slot != nil {
+				slot.held = false
+			}
+			entry = slot
+		}
+		if entry == nil || entry == node.value.entry {
+			break
+		}
+		if intervalMap.tree.cmpFn(entry.key, node.key) < 0 {
+			node = node.less
+		} else {
+			node = node.greater
+		}
+	}
+}
+
+// Refills the empty slots of the changed nodes of a subtree bottom-up
+func (intervalMap *IntervalMap[K, V]) refillSlots(node *intervalNode[K, V]) {
+	if node != nil && node.value.dirty {
+		intervalMap.refillSlots(node.less)
+		intervalMap.refillSlots(node.greater)
+		node.value.dirty = false
+		intervalMap.pullUp(node)
+	}
+}
+
+// Fills the empty slot of a node with the entry with the greatest end among the
+// node's own entry, if it is not held, and the entries in the slots of its
+// children
+//
+// A child whose slot's entry was taken is refilled the same way.
+func (intervalMap *IntervalMap[K, V]) pullUp(node *intervalNode[K, V]) {
+	for node != nil {
+		var best *intervalEntry[K, V] = nil
+		var bestChild *intervalNode[K, V] = nil
+		if !node.value.entry.held {
+			best = node.value.entry
+		}
+		for _, child := range [2]*intervalNode[K, V]{node.less, node.greater} {
+			if child != nil && child.value.slot != nil &&
+				(best == nil || intervalMap.cmpFn(child.value.slot.end, best.end) > 0) {
+				best = child.value.slot
+				bestChild = child
+			}
+		}
+		node.value.slot = best
+		if best != nil {
+			best.held = true
+		}
+		if bestChild != nil {
+			bestChild.value.slot = nil
+		}
+		node = bestChild
+	}
+}
@@ -0,0 +1,219 @@
+// IntervalMap tests -- stabbing and overlap queries checked against a linear scan
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+import (
+	"cmp"
+	"math/rand"
+	"testing"
+)
+
+type testInterval struct {
+	start int
+	end   int
+	value int
+}
+
+// Appends the entries of a subtree to a list
+func appendSubtreeEntries(entries []*intervalEntry[int, int], node *intervalNode[int, int]) []*intervalEntry[int, int] {
+	if node != nil {
+		entries = append(entries, node.value.entry)
+		entries = appendSubtreeEntries(entries, node.less)
+		entries = appendSubtreeEntries(entries, node.greater)
+	}
+	return entries
+}
+
+// Checks that the slot of each node holds the entry with the greatest end among
+// the entries in the node's subtree that are not held by an ancestor node
+func checkIntervalSlots(t *testing.T, intervalMap *IntervalMap[int, int]) {
+	t.Helper()
+	if err := intervalMap.tree.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	// Entries that are held by the slots of the current node's ancestors
+	held := make(map[*intervalEntry[int, int]]bool)
+	slotCount := 0
+	var checkWalk func(node *intervalNode[int, int])
+	checkWalk = func(node *intervalNode[int, int]) {
+		if node != nil {
+			slot := node.value.slot
+			var best *intervalEntry[int, int] = nil
+			slotFound := false
+			for _, entry := range appendSubtreeEntries(nil, node) {
+				if !held[entry] {
+					slotFound = slotFound || entry == slot
+					if best == nil || entry.end > best.end {
+						best = entry
+					}
+				}
+			}
+			if (slot == nil) != (best == nil) || (slot != nil && (!slotFound || slot.end != best.end)) {
+				t.Fatalf("slot of node %v does not hold the greatest end of its subtree", node.key)
+			}
+			if slot != nil {
+				if !slot.held {
+					t.Fatalf("entry %v is held, but not flagged as held", slot.interval())
+				}
+				held[slot] = true
+				slotCount++
+			}
+			checkWalk(node.less)
+			checkWalk(node.greater)
+			delete(held, slot)
+		}
+	}
+	checkWalk(intervalMap.tree.root)
+	flagCount := 0
+	for _, entry := range appendSubtreeEntries(nil, intervalMap.tree.root) {
+		if entry.held {
+			flagCount++
+		}
+	}
+	if flagCount != slotCount {
+		t.Fatalf("%d entries are flagged as held, but %d are held", flagCount, slotCount)
+	}
+}
+
+// Checks that a query yields each interval of the reference list that
+// satisfies the predicate exactly once
+func checkIntervalQuery(
+	t *testing.T,
+	name string,
+	query func(yield func(Interval[int], int) bool),
+	ref []testInterval,
+	predicate func(interval testInterval) bool,
+) {
+	t.Helper()
+	expected := make(map[int]Interval[int])
+	for _, interval := range ref {
+		if predicate(interval) {
+			expected[interval.value] = Interval[int]{interval.start, interval.end}
+		}
+	}
+	seen := make(map[int]bool)
+	for interval, value := range query {
+		if expectedInterval, flag := expected[value]; !flag || seen[value] || interval != expectedInterval {
+			t.Fatalf("%s yielded unexpected interval %v=%d", name, interval, value)
+		}
+		seen[value] = true
+	}
+	if len(seen) != len(expected) {
+		t.Fatalf("%s yielded %d intervals, expected %d", name, len(seen), len(expected))
+	}
+}
+
+func TestIntervalMapQueries(t *testing.T) {
+	tests := []struct {
+		name      string
+		pointMax  int
+		lengthMax int
+		opCount   int
+	}{
+		{"short", 1000, 10, 1500},
+		{"long", 1000, 500, 1500},
+		{"many equal starts", 20, 40, 1500},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			random := rand.New(rand.NewSource(7))
+			intervalMap := NewIntervalMapOf[int, int](cmp.Compare[int])
+			var ref []testInterval = nil
+			for idx := 0; idx < test.opCount; idx++ {
+				if random.Intn(3) > 0 || len(ref) == 0 {
+					start := random.Intn(test.pointMax)
+					end := start + 1 + random.Intn(test.lengthMax)
+					if err := intervalMap.Insert(start, end, idx); err != nil {
+						t.Fatal(err)
+					}
+					ref = append(ref, testInterval{start, end, idx})
+				} else {
+					// Remove removes the first inserted of several equal intervals
+					interval := ref[random.Intn(len(ref))]
+					for refIdx, refInterval := range ref {
+						if refInterval.start == interval.start && refInterval.end == interval.end {
+							ref = append(ref[:refIdx], ref[refIdx+1:]...)
+							break
+						}
+					}
+					if !intervalMap.Remove(interval.start, interval.end) {
+						t.Fatalf("interval %v was not removed", interval)
+					}
+				}
+				if intervalMap.GetSize() != len(ref) {
+					t.Fatalf("size is %d, expected %d", intervalMap.GetSize(), len(ref))
+				}
+				if idx%20 == 0 {
+					checkIntervalSlots(t, intervalMap)
+				}
+
+				point := random.Intn(test.pointMax+20) - 10
+				checkIntervalQuery(t, "stab", intervalMap.Stab(point), ref, func(interval testInterval) bool {
+					return interval.start <= point && point < interval.end
+				})
+				start := random.Intn(test.pointMax+20) - 10
+				end := start + random.Intn(test.lengthMax)
+				checkIntervalQuery(t, "overlapping", intervalMap.Overlapping(start, end), ref, func(interval testInterval) bool {
+					return start < end && interval.start < end && start < interval.end
+				})
+			}
+			checkIntervalSlots(t, intervalMap)
+			if intervalMap.Remove(-1, 0) {
+				t.Fatal("removed an interval that was never inserted")
+			}
+		})
+	}
+}
+
+func TestIntervalMapEmptyIntervals(t *testing.T) {
+	intervalMap := NewIntervalMapOf[int, int](cmp.Compare[int])
+	intervalMap.Insert(0, 10, 1)
+	tests := []struct {
+		name  string
+		start int
+		end   int
+	}{
+		{"empty", 5, 5},
+		{"reversed", 6, 4},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := intervalMap.Insert(test.start, test.end, 2); err != ErrEmptyInterval {
+				t.Fatalf("insert returned %v, expected ErrEmptyInterval", err)
+			}
+			for interval := range intervalMap.Overlapping(test.start, test.end) {
+				t.Fatalf("empty query yielded %v", interval)
+			}
+		})
+	}
+	if intervalMap.GetSize() != 1 {
+		t.Fatalf("size is %d, expected 1", intervalMap.GetSize())
+	}
+}
@@ -0,0 +1,345 @@
+// Map encoding -- versioned binary encoding of TreeMaps and VMaps
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"iter"
+	"os"
+	"path/filepath"
+)
+
+// Error that is reported if a map is encoded or decoded before its codecs were set
+var ErrNoCodecs = errors.New("dsaext: key and value codecs are not set")
+
+// Encoded maps have the following layout:
+//
+//	magic     4 bytes, "DSAX"
+//	version   1 byte
+//	kind      1 byte, 'T' for a TreeMap, 'V' for a VMap
+//	count     unsigned varint, number of entries
+//	entries   key and value of each entry, as encoded by the codecs
+//	checksum  4 bytes, big endian CRC-32 (IEEE) of all preceding bytes
+const (
+	encodingMagic       = "DSAX"
+	encodingVersion     = 1
+	encodingKindTreeMap = 'T'
+	encodingKindVMap    = 'V'
+	encodingHeaderSize  = len(encodingMagic) + 2
+	encodingCrcSize     = 4
+)
+
+// Key and value codecs of a map
+type mapCodecs[K, V any] struct {
+	keyCodec   Codec[K]
+	valueCodec Codec[V]
+}
+
+// Sets the codecs that encode and decode the keys and values of the map
+func (tree *TreeMap[K, V]) SetCodecs(keyCodec Codec[K], valueCodec Codec[V]) {
+	tree.settings.codecs = &mapCodecs[K, V]{keyCodec, valueCodec}
+}
+
+// Returns the binary encoding of the map's entries in ascending key order
+//
+// Implements encoding.BinaryMarshaler.
+func (tree *TreeMap[K, V]) MarshalBinary() ([]byte, error) {
+	return encodeEntries(encodingKindTreeMap, tree.size, tree.All(), tree.settings.codecs)
+}
+
+// Replaces the map's entries with the entries decoded from the data
+//
+// The entries must be in strictly ascending key order according to the map's
+// comparison function. The tree is rebuilt in O(n) time without rebalancing.
+// Keys are decoded into the type of the key codec, e.g. InterfaceCodec(IntCodec)
+// decodes int keys for a map with interface{} keys, and that type must be the
+// type that the comparison function expects. If the data cannot be decoded,
+// the map is not modified.
+//
+// Returns ErrNoCompareFn if the map has no comparison function.
+//
+// Implements encoding.BinaryUnmarshaler.
+func (tree *TreeMap[K, V]) UnmarshalBinary(data []byte) error {
+	var entries []Entry[K, V] = nil
+	var err error = nil
+	if tree.cmpFn != nil {
+		entries, err = decodeEntries(encodingKindTreeMap, data, tree.settings.codecs)
+	} else {
+		err = ErrNoCompareFn
+	}
+	if err == nil {
+		for idx := 1; idx < len(entries); idx++ {
+			if tree.cmpFn(entries[idx-1].Key, entries[idx].Key) >= 0 {
+				err = fmt.Errorf("%w: entry %d", ErrNotAscending, idx)
+				break
+			}
+		}
+	}
+	if err == nil {
+		tree.replaceSorted(entries)
+	}
+	return err
+}
+
+// Replaces the map's entries with entries that are sorted in strictly ascending key order
+func (tree *TreeMap[K, V]) replaceSorted(entries []Entry[K, V]) {
+	tree.Clear()
+	nodes := make([]*treeNode[K, V], 0, len(entries))
+	for _, entry := range entries {
+		nodes = append(nodes, tree.allocNode(entry.Key, entry.Value, nil))
+	}
+	tree.root = linkSortedNodes(nodes, nil)
+	tree.size = len(nodes)
+	tree.augmentSubtree(tree.root)
+}
+
+// Implements gob.GobEncoder
+func (tree *TreeMap[K, V]) GobEncode() ([]byte, error) {
+	return tree.MarshalBinary()
+}
+
+// Implements gob.GobDecoder
+//
+// Gob decodes into a zero TreeMap unless the target already holds a map, therefore
+// the target must be a map that was created with a comparison function and that
+// had its codecs set.
+func (tree *TreeMap[K, V]) GobDecode(data []byte) error {
+	return tree.UnmarshalBinary(data)
+}
+
+// Sets the codecs that encode and decode the keys and values of the map
+func (mapObj *VMap[K, V]) SetCodecs(keyCodec Codec[K], valueCodec Codec[V]) {
+	mapObj.settings.codecs = &mapCodecs[K, V]{keyCodec, valueCodec}
+}
+
+// Returns the binary encoding of the map's entries in the order of the map
+//
+// Implements encoding.BinaryMarshaler.
+func (mapObj *VMap[K, V]) MarshalBinary() ([]byte, error) {
+	return encodeEntries(encodingKindVMap, mapObj.size, mapObj.All(), mapObj.settings.codecs)
+}
+
+// Replaces the map's entries with the entries decoded from the data, keeping
+// the order of the entries
+//
+// If the data cannot be decoded, the map is not modified.
+//
+// Implements encoding.BinaryUnmarshaler.
+func (mapObj *VMap[K, V]) UnmarshalBinary(data []byte) error {
+	entries, err := decodeEntries(encodingKindVMap, data, mapObj.settings.codecs)
+	if err == nil {
+		mapObj.Clear()
+		for _, entry := range entries {
+			mapObj.Append(entry.Key, entry.Value)
+		}
+	}
+	return err
+}
+
+// Implements gob.GobEncoder
+func (mapObj *VMap[K, V]) GobEncode() ([]byte, error) {
+	return mapObj.MarshalBinary()
+}
+
+// Implements gob.GobDecoder
+//
+// Gob decodes into a zero VMap unless the target already holds a map, therefore
+// the target must be a map that was created with a comparison function and that
+// had its codecs set.
+func (mapObj *VMap[K, V]) GobDecode(data []byte) error {
+	return mapObj.UnmarshalBinary(data)
+}
+
+func encodeEntries[K, V any](kind byte, size int, entries iter.Seq2[K, V], codecs *mapCodecs[K, V]) ([]byte, error) {
+	var data []byte = nil
+	var err error = nil
+	if codecs != nil {
+		data = make([]byte, 0, encodingHeaderSize+binary.MaxVarintLen64+size*2+encodingCrcSize)
+		data = append(data, encodingMagic...)
+		data = append(data, encodingVersion, kind)
+		data = binary.AppendUvarint(data, uint64(size))
+		for key, value := range entries {
+			data = codecs.keyCodec.Append(data, key)
+			data = codecs.valueCodec.Append(data, value)
+		}
+		data = binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(data))
+	} else {
+		err = ErrNoCodecs
+	}
+	return data, err
+}
+
+func decodeEntries[K, V any](kind byte, data []byte, codecs *mapCodecs[K, V]) ([]Entry[K, V], error) {
+	var entries []Entry[K, V] = nil
+	var err error = nil
+	if codecs == nil {
+		err = ErrNoCodecs
+	} else if len(data) < encodingHeaderSize+1+encodingCrcSize {
+		err = fmt.Errorf("%w: truncated data", ErrInvalidEncoding)
+	} else if !bytes.Equal(data[:len(encodingMagic)], []byte(encodingMagic)) {
+		err = fmt.Errorf("%w: bad magic", ErrInvalidEncoding)
+	} else if data[len(encodingMagic)] != encodingVersion {
+		err = fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, data[len(encodingMagic)])
+	} else if data[len(encodingMagic)+1] != kind {
+		err = fmt.Errorf("%w: kind %q, expected %q", ErrInvalidEncoding, data[len(encodingMagic)+1], kind)
+	} else {
+		payload := data[:len(data)-encodingCrcSize]
+		checksum := binary.BigEndian.Uint32(data[len(payload):])
+		if crc32.ChecksumIEEE(payload) != checksum {
+			err = fmt.Errorf("%w: checksum mismatch", ErrInvalidEncoding)
+		} else {
+			entries, err = decodePayload(payload[encodingHeaderSize:], codecs)
+		}
+	}
+	return entries, err
+}
+
+func decodePayload[K, V any](data []byte, codecs *mapCodecs[K, V]) ([]Entry[K, V], error) {
+	var entries []Entry[K, V] = nil
+	var err error = nil
+	count, offset := binary.Uvarint(data)
+	if offset > 0 {
+		// The count is only trusted as far as the data could hold that many entries
+		entries = make([]Entry[K, V], 0, min(count, uint64(len(data))))
+	} else {
+		err = fmt.Errorf("%w: bad entry count", ErrInvalidEncoding)
+	}
+	for idx := uint64(0); idx < count && err == nil; idx++ {
+		var entry Entry[K, V]
+		var length int
+		entry.Key, length, err = codecs.keyCodec.Decode(data[offset:])
+		if err == nil {
+			offset, err = advanceOffset(data, offset, length)
+		}
+		if err == nil {
+			entry.Value, length, err = codecs.valueCodec.Decode(data[offset:])
+		}
+		if err == nil {
+			offset, err = advanceOffset(data, offset, length)
+		}
+		if err == nil {
+			entries = append(entries, entry)
+		} else {
+			err = fmt.Errorf("entry %d: %w", idx, err)
+		}
+	}
+	if err == nil && offset != len(data) {
+		err = fmt.Errorf("%w: %d trailing bytes", ErrInvalidEncoding, len(data)-offset)
+	}
+	return entries, err
+}
+
+// Advances the offset past a decoded key or value, checking that the codec
+// did not report a length beyond the end of the data
+func advanceOffset(data []byte, offset, length int) (int, error) {
+	var err error = nil
+	if length >= 0 && length <= len(data)-offset {
+		offset += length
+	} else {
+		err = fmt.Errorf("%w: bad length %d", ErrInvalidEncoding, length)
+	}
+	return offset, err
+}
+
+// Writes the binary encoding of the map to a file
+//
+// The data is written to a temporary file in the same directory, which is then
+// renamed to the specified path, so that the file is either replaced entirely
+// or left unchanged. A replaced file keeps its permissions, a new file is
+// created with permissions 0644. The directory is synced after the rename, so
+// that the replacement survives a crash.
+func SaveFile(path string, mapObj encoding.BinaryMarshaler) error {
+	data, err := mapObj.MarshalBinary()
+	var mode os.FileMode = 0644
+	if err == nil {
+		var info os.FileInfo
+		info, err = os.Stat(path)
+		if err == nil {
+			mode = info.Mode().Perm()
+		} else if errors.Is(err, os.ErrNotExist) {
+			err = nil
+		}
+	}
+	if err == nil {
+		dirPath := filepath.Dir(path)
+		var file *os.File
+		file, err = os.CreateTemp(dirPath, filepath.Base(path)+".tmp*")
+		if err == nil {
+			_, err = file.Write(data)
+			if err == nil {
+				err = file.Chmod(mode)
+			}
+			if err == nil {
+				err = file.Sync()
+			}
+			closeErr := file.Close()
+			if err == nil {
+				err = closeErr
+			}
+			if err == nil {
+				err = os.Rename(file.Name(), path)
+			}
+			if err == nil {
+				err = syncDir(dirPath)
+			} else {
+				os.Remove(file.Name())
+			}
+		}
+	}
+	return err
+}
+
+// Flushes the directory's entries, such as a renamed file, to stable storage
+func syncDir(dirPath string) error {
+	dir, err := os.Open(dirPath)
+	if err == nil {
+		err = dir.Sync()
+		closeErr := dir.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// Replaces the entries of the map with the entries decoded from a file that
+// was written by SaveFile
+func LoadFile(path string, mapObj encoding.BinaryUnmarshaler) error {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		err = mapObj.UnmarshalBinary(data)
+	}
+	return err
+}
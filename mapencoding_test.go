@@ -0,0 +1,236 @@
+// Map encoding tests -- decoding of corrupt and truncated binary and JSON data
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"iter"
+	"testing"
+)
+
+// Creates a map with int keys and values that has its codecs set and
+// returns it together with its reference entries
+func encodingTestMap() (*TreeMap[int, int], map[int]int) {
+	tree := NewTreeMapOf[int, int](cmp.Compare[int])
+	tree.SetCodecs(IntCodec, IntCodec)
+	ref := make(map[int]int)
+	for key := -50; key < 50; key += 3 {
+		tree.Insert(key, key*key)
+		ref[key] = key * key
+	}
+	return tree, ref
+}
+
+// Returns a sequence of the specified keys with the keys as values
+func keySeq(keys ...int) iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		for _, key := range keys {
+			if !yield(key, key) {
+				break
+			}
+		}
+	}
+}
+
+// Replaces the checksum at the end of the data with the checksum of the
+// preceding bytes
+func fixChecksum(data []byte) []byte {
+	payload := data[:len(data)-encodingCrcSize]
+	return binary.BigEndian.AppendUint32(payload, crc32.ChecksumIEEE(payload))
+}
+
+func TestTreeMapUnmarshalBinaryCorrupt(t *testing.T) {
+	codecs := &mapCodecs[int, int]{IntCodec, IntCodec}
+	validData, err := encodeEntries(encodingKindTreeMap, 3, keySeq(1, 2, 3), codecs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	descendingData, _ := encodeEntries(encodingKindTreeMap, 3, keySeq(1, 3, 2), codecs)
+	duplicateData, _ := encodeEntries(encodingKindTreeMap, 3, keySeq(1, 2, 2), codecs)
+	shortCountData, _ := encodeEntries(encodingKindTreeMap, 2, keySeq(1, 2, 3), codecs)
+	longCountData, _ := encodeEntries(encodingKindTreeMap, 4, keySeq(1, 2, 3), codecs)
+	hugeCountData := append([]byte(encodingMagic), encodingVersion, encodingKindTreeMap)
+	hugeCountData = binary.AppendUvarint(hugeCountData, 1<<62)
+	hugeCountData = fixChecksum(append(IntCodec.Append(hugeCountData, 1), 1, 0, 0, 0, 0))
+	vmapData, _ := encodeEntries(encodingKindVMap, 3, keySeq(1, 2, 3), codecs)
+	badVersionData := append([]byte(nil), validData...)
+	badVersionData[len(encodingMagic)] = encodingVersion + 1
+	badMagicData := append([]byte(nil), validData...)
+	badMagicData[0] = 'X'
+	flippedData := append([]byte(nil), validData...)
+	flippedData[encodingHeaderSize+2] ^= 0x40
+
+	tests := []struct {
+		name        string
+		data        []byte
+		expectedErr error
+	}{
+		{"nil", nil, ErrInvalidEncoding},
+		{"header only", validData[:encodingHeaderSize], ErrInvalidEncoding},
+		{"bad magic", fixChecksum(badMagicData), ErrInvalidEncoding},
+		{"bad version", fixChecksum(badVersionData), ErrInvalidEncoding},
+		{"VMap data", vmapData, ErrInvalidEncoding},
+		{"flipped bit", flippedData, ErrInvalidEncoding},
+		{"trailing byte", fixChecksum(append(append([]byte(nil), validData...), 0)), ErrInvalidEncoding},
+		{"count too low", shortCountData, ErrInvalidEncoding},
+		{"count too high", longCountData, ErrInvalidEncoding},
+		{"huge count", hugeCountData, ErrInvalidEncoding},
+		{"descending keys", descendingData, ErrNotAscending},
+		{"duplicate keys", duplicateData, ErrNotAscending},
+	}
+	for idx := 0; idx < len(validData); idx++ {
+		tests = append(tests, struct {
+			name        string
+			data        []byte
+			expectedErr error
+		}{"truncated", validData[:idx], ErrInvalidEncoding})
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tree, ref := encodingTestMap()
+			err := tree.UnmarshalBinary(test.data)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("decoding returned %v, expected %v", err, test.expectedErr)
+			}
+			// A failed decoding leaves the map unchanged
+			checkTreeMap(t, tree, ref)
+		})
+	}
+
+	tree, _ := encodingTestMap()
+	if err := tree.UnmarshalBinary(validData); err != nil {
+		t.Fatal(err)
+	}
+	checkTreeMap(t, tree, map[int]int{1: 1, 2: 2, 3: 3})
+}
+
+func TestTreeMapUnmarshalBinarySettings(t *testing.T) {
+	data, _ := encodeEntries(encodingKindTreeMap, 1, keySeq(1), &mapCodecs[int, int]{IntCodec, IntCodec})
+	tests := []struct {
+		name        string
+		tree        *TreeMap[int, int]
+		expectedErr error
+	}{
+		{"no codecs", NewTreeMapOf[int, int](cmp.Compare[int]), ErrNoCodecs},
+		{"no compare function", &TreeMap[int, int]{}, ErrNoCompareFn},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.tree.UnmarshalBinary(data); !errors.Is(err, test.expectedErr) {
+				t.Fatalf("decoding returned %v, expected %v", err, test.expectedErr)
+			}
+			checkTreeMap(t, NewTreeMapOf[int, int](cmp.Compare[int]), map[int]int{})
+		})
+	}
+}
+
+func TestTreeMapUnmarshalJSONCorrupt(t *testing.T) {
+	// A nil expected error accepts any error
+	tests := []struct {
+		name        string
+		data        string
+		expectedErr error
+	}{
+		{"empty", "", nil},
+		{"string", `"x"`, ErrInvalidJSON},
+		{"number", `1`, ErrInvalidJSON},
+		{"trailing data", `{"1":1} {}`, ErrInvalidJSON},
+		{"non-numeric member name", `{"a":1}`, ErrJSONKey},
+		{"bad value", `{"1":"x"}`, nil},
+		{"unclosed object", `{"1":1`, nil},
+		{"short pair", `[[1]]`, ErrInvalidJSON},
+		{"long pair", `[[1,2,3]]`, ErrInvalidJSON},
+		{"pair is not an array", `[1]`, nil},
+		{"bad pair key", `[["a",1]]`, nil},
+		{"unclosed pairs", `[[1,2],`, nil},
+	}
+	validData := `{"1":1,"2":4,"-3":9}`
+	for idx := 1; idx < len(validData); idx++ {
+		tests = append(tests, struct {
+			name        string
+			data        string
+			expectedErr error
+		}{"truncated object", validData[:idx], nil})
+	}
+	validPairs := `[[1,1],[2,4],[-3,9]]`
+	for idx := 1; idx < len(validPairs); idx++ {
+		tests = append(tests, struct {
+			name        string
+			data        string
+			expectedErr error
+		}{"truncated pairs", validPairs[:idx], nil})
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tree, ref := encodingTestMap()
+			err := tree.UnmarshalJSON([]byte(test.data))
+			if err == nil || (test.expectedErr != nil && !errors.Is(err, test.expectedErr)) {
+				t.Fatalf("decoding %q returned %v, expected %v", test.data, err, test.expectedErr)
+			}
+			// A failed decoding leaves the map unchanged
+			checkTreeMap(t, tree, ref)
+		})
+	}
+
+	for _, data := range []string{validData, validPairs} {
+		tree, _ := encodingTestMap()
+		if err := tree.UnmarshalJSON([]byte(data)); err != nil {
+			t.Fatal(err)
+		}
+		checkTreeMap(t, tree, map[int]int{-3: 9, 1: 1, 2: 4})
+	}
+}
+
+func TestTreeMapUnmarshalJSONKeyType(t *testing.T) {
+	tree := NewTreeMap(CompareInt)
+	tree.Insert(1, 1)
+	if err := tree.UnmarshalJSON([]byte(`[[2,2]]`)); !errors.Is(err, ErrJSONKeyType) {
+		t.Fatalf("decoding without a key decoder returned %v, expected ErrJSONKeyType", err)
+	}
+	tree.SetJSONKeyDecoder(InterfaceJSONKey[int])
+	if err := tree.UnmarshalJSON([]byte(`[["x",2]]`)); !errors.Is(err, ErrJSONKeyType) {
+		t.Fatalf("decoding a string key returned %v, expected ErrJSONKeyType", err)
+	}
+	if value, flag := tree.Get(1); tree.GetSize() != 1 || !flag || value != 1 {
+		t.Fatal("failed decoding modified the map")
+	}
+	if err := tree.UnmarshalJSON([]byte(`{"3":3,"2":2}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if key, _ := tree.GetFirstKey(); tree.GetSize() != 2 || key != 2 {
+		t.Fatalf("decoded map has %d entries and first key %v", tree.GetSize(), key)
+	}
+}
@@ -0,0 +1,379 @@
+// Map JSON encoding -- order preserving JSON encoding of TreeMaps and VMaps
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"slices"
+)
+
+// Error that is reported if a key cannot be used as the name of a JSON object member
+var ErrJSONKey = errors.New("dsaext: key cannot be encoded as a JSON object member name")
+
+// Error that is reported if JSON data is neither an object nor an array of key/value pairs
+var ErrInvalidJSON = errors.New("dsaext: JSON value is not an object or an array of key/value pairs")
+
+// Error that is reported if a key decoded from JSON cannot be converted into the
+// type that the map's comparison function expects, or if a map with keys of an
+// interface type has no key decoder
+var ErrJSONKeyType = errors.New("dsaext: decoded key does not have the type that the comparison function expects")
+
+// Error that is reported if data is decoded into a TreeMap that was not created
+// with a comparison function
+var ErrNoCompareFn = errors.New("dsaext: map has no comparison function")
+
+// Function that converts a key decoded from JSON into the type that the map's
+// comparison function expects
+type jsonKeyFn[K any] func(key K) (K, error)
+
+// Key decoder for maps with interface{} keys that converts each decoded key into
+// a value of type T
+//
+// Keys that were encoded as numbers are decoded from the member names of the
+// object format as well as from the pairs format. Returns ErrJSONKeyType if a key
+// cannot be converted.
+func InterfaceJSONKey[T any](key interface{}) (interface{}, error) {
+	var typedKey T
+	data, err := json.Marshal(key)
+	if err == nil {
+		err = json.Unmarshal(data, &typedKey)
+	}
+	if text, isText := key.(string); err != nil && isText {
+		// Member name of the object format, e.g. "1" for the number 1
+		err = json.Unmarshal([]byte(text), &typedKey)
+	}
+	if err != nil {
+		err = fmt.Errorf("%w: %v", ErrJSONKeyType, key)
+	}
+	return typedKey, err
+}
+
+// Selects whether the map is encoded as a JSON array of [key, value] pairs
+// instead of a JSON object
+//
+// Only keys that encode to JSON strings or numbers can be used in the object
+// format. Decoding accepts both formats regardless of this setting.
+func (tree *TreeMap[K, V]) SetJSONPairs(pairs bool) {
+	tree.settings.jsonPairs = pairs
+}
+
+// Returns the JSON encoding of the map's entries in ascending key order
+//
+// Implements json.Marshaler.
+func (tree *TreeMap[K, V]) MarshalJSON() ([]byte, error) {
+	return marshalJSONEntries(tree.All(), tree.settings.jsonPairs)
+}
+
+// Sets a function that converts each key decoded from JSON before it is compared
+// or stored
+//
+// encoding/json decodes keys of type interface{} as strings in the object format
+// and as float64, string, bool, []interface{} or map[string]interface{} in the
+// pairs format, which is not necessarily the type that the comparison function
+// expects. Maps with keys of an interface type therefore require a key decoder,
+// such as InterfaceJSONKey[int] for a map that uses CompareInt, like the binary
+// encoding requires a key codec, such as InterfaceCodec(IntCodec). Maps with keys
+// of other types decode keys into their key type and need no key decoder.
+// A nil function uses keys as they are decoded.
+func (tree *TreeMap[K, V]) SetJSONKeyDecoder(keyFn jsonKeyFn[K]) {
+	tree.settings.jsonKeyFn = keyFn
+}
+
+// Replaces the map's entries with the entries decoded from a JSON object or from
+// a JSON array of [key, value] pairs
+//
+// If the keys are in strictly ascending order, the tree is rebuilt in O(n) time
+// without rebalancing, otherwise the entries are sorted first, and a later entry
+// replaces an earlier one with the same key. Keys are converted by the function
+// set with SetJSONKeyDecoder. ErrJSONKeyType is returned if the map's keys are of
+// an interface type and no key decoder is set. If the data cannot be decoded,
+// the map is not modified.
+//
+// Returns ErrNoCompareFn if the map has no comparison function, which is the case
+// if encoding/json allocated the map for a nil pointer.
+//
+// Implements json.Unmarshaler.
+func (tree *TreeMap[K, V]) UnmarshalJSON(data []byte) error {
+	var entries []Entry[K, V] = nil
+	isNull := false
+	var err error = nil
+	if tree.cmpFn != nil {
+		entries, isNull, err = unmarshalJSONEntries[K, V](data, tree.settings.jsonKeyFn)
+	} else {
+		err = ErrNoCompareFn
+	}
+	if err == nil && !isNull {
+		tree.replaceSorted(sortJSONEntries(entries, tree.cmpFn))
+	}
+	return err
+}
+
+// Sorts decoded entries in strictly ascending key order, keeping the last of
+// several entries with the same key
+func sortJSONEntries[K, V any](entries []Entry[K, V], cmpFn compareFn[K]) []Entry[K, V] {
+	ascending := true
+	for idx := 1; idx < len(entries) && ascending; idx++ {
+		ascending = cmpFn(entries[idx-1].Key, entries[idx].Key) < 0
+	}
+	sorted := entries
+	if !ascending {
+		slices.SortStableFunc(entries, func(entry1st, entry2nd Entry[K, V]) int {
+			return cmpFn(entry1st.Key, entry2nd.Key)
+		})
+		sorted = entries[:0]
+		for idx, entry := range entries {
+			if idx+1 < len(entries) && cmpFn(entry.Key, entries[idx+1].Key) == 0 {
+				// A later entry replaces this one
+				continue
+			}
+			sorted = append(sorted, entry)
+		}
+	}
+	return sorted
+}
+
+// Selects whether the map is encoded as a JSON array of [key, value] pairs
+// instead of a JSON object
+//
+// Only keys that encode to JSON strings or numbers can be used in the object
+// format. Decoding accepts both formats regardless of this setting.
+func (mapObj *VMap[K, V]) SetJSONPairs(pairs bool) {
+	mapObj.settings.jsonPairs = pairs
+}
+
+// Returns the JSON encoding of the map's entries in the order of the map
+//
+// Implements json.Marshaler.
+func (mapObj *VMap[K, V]) MarshalJSON() ([]byte, error) {
+	return marshalJSONEntries(mapObj.All(), mapObj.settings.jsonPairs)
+}
+
+// Sets a function that converts each key decoded from JSON before it is stored
+//
+// The same conversions as for TreeMap.SetJSONKeyDecoder apply.
+func (mapObj *VMap[K, V]) SetJSONKeyDecoder(keyFn jsonKeyFn[K]) {
+	mapObj.settings.jsonKeyFn = keyFn
+}
+
+// Replaces the map's entries with the entries decoded from a JSON object or from
+// a JSON array of [key, value] pairs, keeping the order of the entries
+//
+// Keys and values are decoded by encoding/json, which decodes JSON values into
+// interface{} keys or values as strings, float64, bool, []interface{} and
+// map[string]interface{}. Keys are then converted by the function set with
+// SetJSONKeyDecoder, which is required for keys of an interface type, like for
+// a TreeMap. If the data cannot be decoded, the map is not modified.
+//
+// Implements json.Unmarshaler.
+func (mapObj *VMap[K, V]) UnmarshalJSON(data []byte) error {
+	entries, isNull, err := unmarshalJSONEntries[K, V](data, mapObj.settings.jsonKeyFn)
+	if err == nil && !isNull {
+		mapObj.Clear()
+		for _, entry := range entries {
+			mapObj.Append(entry.Key, entry.Value)
+		}
+	}
+	return err
+}
+
+func marshalJSONEntries[K, V any](entries iter.Seq2[K, V], pairs bool) ([]byte, error) {
+	var buffer bytes.Buffer
+	var err error = nil
+	if pairs {
+		buffer.WriteByte('[')
+	} else {
+		buffer.WriteByte('{')
+	}
+	first := true
+	for key, value := range entries {
+		var keyData, valueData []byte
+		keyData, err = json.Marshal(key)
+		if err == nil && !pairs {
+			keyData, err = jsonMemberName(keyData)
+		}
+		if err == nil {
+			valueData, err = json.Marshal(value)
+		}
+		if err != nil {
+			break
+		}
+		if !first {
+			buffer.WriteByte(',')
+		}
+		first = false
+		if pairs {
+			buffer.WriteByte('[')
+			buffer.Write(keyData)
+			buffer.WriteByte(',')
+			buffer.Write(valueData)
+			buffer.WriteByte(']')
+		} else {
+			buffer.Write(keyData)
+			buffer.WriteByte(':')
+			buffer.Write(valueData)
+		}
+	}
+	if pairs {
+		buffer.WriteByte(']')
+	} else {
+		buffer.WriteByte('}')
+	}
+	var data []byte = nil
+	if err == nil {
+		data = buffer.Bytes()
+	}
+	return data, err
+}
+
+// Returns the member name for the JSON encoding of a key
+//
+// Strings are used as they are, and numbers are quoted, like encoding/json does
+// for the keys of Go maps.
+func jsonMemberName(keyData []byte) ([]byte, error) {
+	var name []byte = nil
+	var err error = nil
+	if len(keyData) > 0 && keyData[0] == '"' {
+		name = keyData
+	} else if len(keyData) > 0 && (keyData[0] == '-' || (keyData[0] >= '0' && keyData[0] <= '9')) {
+		name = make([]byte, 0, len(keyData)+2)
+		name = append(name, '"')
+		name = append(name, keyData...)
+		name = append(name, '"')
+	} else {
+		err = fmt.Errorf("%w: %s", ErrJSONKey, keyData)
+	}
+	return name, err
+}
+
+// Decodes the entries of a JSON object or of a JSON array of [key, value] pairs
+// and converts their keys by the key function, which must be set for keys of
+// an interface type
+//
+// Returns true if the data is the JSON null value, which by convention leaves the
+// target unchanged.
+func unmarshalJSONEntries[K, V any](data []byte, keyFn jsonKeyFn[K]) ([]Entry[K, V], bool, error) {
+	var entries []Entry[K, V] = nil
+	isNull := false
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err == nil {
+		switch token {
+		case json.Delim('{'):
+			entries, err = decodeJSONObject[K, V](decoder)
+		case json.Delim('['):
+			entries, err = decodeJSONPairs[K, V](decoder)
+		case nil:
+			isNull = true
+		default:
+			err = ErrInvalidJSON
+		}
+	}
+	if err == nil {
+		// Consume the closing delimiter and check for trailing data
+		if !isNull {
+			_, err = decoder.Token()
+		}
+		if err == nil {
+			if _, trailingErr := decoder.Token(); trailingErr != io.EOF {
+				err = fmt.Errorf("%w: trailing data", ErrInvalidJSON)
+			}
+		}
+	}
+	if err == nil && !isNull && keyFn == nil && reflect.TypeFor[K]().Kind() == reflect.Interface {
+		err = fmt.Errorf("%w: keys of an interface type require a key decoder", ErrJSONKeyType)
+	}
+	if err == nil && keyFn != nil {
+		for idx := range entries {
+			entries[idx].Key, err = keyFn(entries[idx].Key)
+			if err != nil {
+				err = fmt.Errorf("entry %d: %w", idx, err)
+				break
+			}
+		}
+	}
+	return entries, isNull, err
+}
+
+func decodeJSONObject[K, V any](decoder *json.Decoder) ([]Entry[K, V], error) {
+	var entries []Entry[K, V] = nil
+	var err error = nil
+	for decoder.More() && err == nil {
+		var entry Entry[K, V]
+		var token json.Token
+		token, err = decoder.Token()
+		if err == nil {
+			// Member names are always strings, try decoding the name as a string
+			// first and fall back to decoding its content, e.g. for numeric keys
+			name := token.(string)
+			quotedName, _ := json.Marshal(name)
+			if json.Unmarshal(quotedName, &entry.Key) != nil {
+				if json.Unmarshal([]byte(name), &entry.Key) != nil {
+					err = fmt.Errorf("%w: %q", ErrJSONKey, name)
+				}
+			}
+		}
+		if err == nil {
+			err = decoder.Decode(&entry.Value)
+		}
+		if err == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, err
+}
+
+func decodeJSONPairs[K, V any](decoder *json.Decoder) ([]Entry[K, V], error) {
+	var entries []Entry[K, V] = nil
+	var err error = nil
+	for decoder.More() && err == nil {
+		var pair []json.RawMessage
+		err = decoder.Decode(&pair)
+		if err == nil && len(pair) != 2 {
+			err = fmt.Errorf("%w: entry %d has %d elements", ErrInvalidJSON, len(entries), len(pair))
+		}
+		var entry Entry[K, V]
+		if err == nil {
+			err = json.Unmarshal(pair[0], &entry.Key)
+		}
+		if err == nil {
+			err = json.Unmarshal(pair[1], &entry.Value)
+		}
+		if err == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, err
+}
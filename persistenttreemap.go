@@ -0,0 +1,352 @@
+// PersistentTreeMap -- immutable balanced binary search tree implementation of a key/value map
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+import (
+	"iter"
+)
+
+// Tree node of a PersistentTreeMap
+//
+// Nodes are never modified after they have become part of a map, which allows
+// any number of maps to share a node. For the same reason, nodes do not have
+// a parent pointer.
+type persistentNode[K, V any] struct {
+	key     K
+	value   V
+	less    *persistentNode[K, V]
+	greater *persistentNode[K, V]
+	level   int
+}
+
+func newPersistentNode[K, V any](key K, value V) *persistentNode[K, V] {
+	return &persistentNode[K, V]{key, value, nil, nil, 1}
+}
+
+func (node *persistentNode[K, V]) clone() *persistentNode[K, V] {
+	nodeCopy := *node
+	return &nodeCopy
+}
+
+func (node *persistentNode[K, V]) nodeLevel() int {
+	var level int = 0
+	if node != nil {
+		level = node.level
+	}
+	return level
+}
+
+// Immutable key/value map
+//
+// Insert and Remove return a new map that shares all unchanged subtrees with
+// the map that the operation was applied to, and leave that map unchanged.
+// Each version of the map can be read concurrently by any number of goroutines.
+type PersistentTreeMap[K, V any] struct {
+	root  *persistentNode[K, V]
+	size  int
+	cmpFn compareFn[K]
+}
+
+type PersistentTreeMapIterator[K, V any] struct {
+	// Path of nodes that have not been visited yet, the next node is on top
+	stack []*persistentNode[K, V]
+}
+
+func (iter *PersistentTreeMapIterator[K, V]) Next() (K, V, bool) {
+	var key K
+	var value V
+	flag := false
+	if len(iter.stack) > 0 {
+		node := iter.stack[len(iter.stack)-1]
+		iter.stack = iter.stack[:len(iter.stack)-1]
+		key = node.key
+		value = node.value
+		flag = true
+		iter.pushLess(node.greater)
+	}
+	return key, value, flag
+}
+
+func (iter *PersistentTreeMapIterator[K, V]) pushLess(node *persistentNode[K, V]) {
+	for node != nil {
+		iter.stack = append(iter.stack, node)
+		node = node.less
+	}
+}
+
+// Creates an empty PersistentTreeMap with keys and values of type interface{}
+func NewPersistentTreeMap(cmpFn compareFn[interface{}]) *PersistentTreeMap[interface{}, interface{}] {
+	return NewPersistentTreeMapOf[interface{}, interface{}](cmpFn)
+}
+
+// Creates an empty PersistentTreeMap with keys of type K and values of type V
+func NewPersistentTreeMapOf[K, V any](cmpFn compareFn[K]) *PersistentTreeMap[K, V] {
+	return &PersistentTreeMap[K, V]{nil, 0, cmpFn}
+}
+
+func (tree *PersistentTreeMap[K, V]) Iterator() *PersistentTreeMapIterator[K, V] {
+	iter := &PersistentTreeMapIterator[K, V]{make([]*persistentNode[K, V], 0, tree.root.nodeLevel()*2)}
+	iter.pushLess(tree.root)
+	return iter
+}
+
+// Returns a sequence of all entries in ascending key order
+func (tree *PersistentTreeMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		iter := tree.Iterator()
+		for key, value, flag := iter.Next(); flag; key, value, flag = iter.Next() {
+			if !yield(key, value) {
+				break
+			}
+		}
+	}
+}
+
+// Returns a map that contains the specified entry in addition to the
+// entries of this map
+func (tree *PersistentTreeMap[K, V]) Insert(key K, value V) *PersistentTreeMap[K, V] {
+	root, added := tree.insertWalk(tree.root, key, value)
+	size := tree.size
+	if added {
+		size++
+	}
+	return &PersistentTreeMap[K, V]{root, size, tree.cmpFn}
+}
+
+func (tree *PersistentTreeMap[K, V]) insertWalk(node *persistentNode[K, V], key K, value V) (*persistentNode[K, V], bool) {
+	var retNode *persistentNode[K, V] = nil
+	var added bool = false
+	if node == nil {
+		retNode = newPersistentNode(key, value)
+		added = true
+	} else {
+		retNode = node.clone()
+		dir := tree.cmpFn(key, node.key)
+		if dir < 0 {
+			retNode.less, added = tree.insertWalk(node.less, key, value)
+			// Rebalance the tree after an insertion
+			retNode = persistentSkew(retNode)
+			retNode = persistentSplit(retNode)
+		} else if dir > 0 {
+			retNode.greater, added = tree.insertWalk(node.greater, key, value)
+			// Rebalance the tree after an insertion
+			retNode = persistentSkew(retNode)
+			retNode = persistentSplit(retNode)
+		} else {
+			// Update the copy of the existing item
+			retNode.value = value
+		}
+	}
+	return retNode, added
+}
+
+// Returns a map that contains the entries of this map except for the entry
+// with the specified key
+//
+// If the map does not contain the key, the map itself is returned.
+func (tree *PersistentTreeMap[K, V]) Remove(key K) *PersistentTreeMap[K, V] {
+	retTree := tree
+	root, removed := tree.removeWalk(tree.root, key)
+	if removed {
+		retTree = &PersistentTreeMap[K, V]{root, tree.size - 1, tree.cmpFn}
+	}
+	return retTree
+}
+
+func (tree *PersistentTreeMap[K, V]) removeWalk(node *persistentNode[K, V], key K) (*persistentNode[K, V], bool) {
+	retNode := node
+	removed := false
+	if node != nil {
+		dir := tree.cmpFn(key, node.key)
+		if dir < 0 {
+			var subNode *persistentNode[K, V]
+			subNode, removed = tree.removeWalk(node.less, key)
+			if removed {
+				retNode = node.clone()
+				retNode.less = subNode
+			}
+		} else if dir > 0 {
+			var subNode *persistentNode[K, V]
+			subNode, removed = tree.removeWalk(node.greater, key)
+			if removed {
+				retNode = node.clone()
+				retNode.greater = subNode
+			}
+		} else {
+			removed = true
+			if node.less != nil {
+				// Find predecessor node
+				delNode := node.less
+				for delNode.greater != nil {
+					delNode = delNode.greater
+				}
+				// Copy value and remove leaf
+				retNode = node.clone()
+				retNode.key = delNode.key
+				retNode.value = delNode.value
+				retNode.less, _ = tree.removeWalk(node.less, delNode.key)
+			} else if node.greater != nil {
+				// Find successor node
+				delNode := node.greater
+				for delNode.less != nil {
+					delNode = delNode.less
+				}
+				// Copy value and remove leaf
+				retNode = node.clone()
+				retNode.key = delNode.key
+				retNode.value = delNode.value
+				retNode.greater, _ = tree.removeWalk(node.greater, delNode.key)
+			} else {
+				retNode = nil
+			}
+		}
+	}
+
+	if removed && retNode != nil {
+		// retNode is a copy that is not shared yet and can be modified
+
+		// Adjust the balance level
+		maxLevel := retNode.less.nodeLevel()
+		if retNode.greater.nodeLevel() < maxLevel {
+			maxLevel = retNode.greater.nodeLevel()
+		}
+		maxLevel++
+		if retNode.level > maxLevel {
+			retNode.level = maxLevel
+			if retNode.greater != nil && retNode.greater.level > maxLevel {
+				retNode.greater = retNode.greater.clone()
+				retNode.greater.level = maxLevel
+			}
+		}
+
+		// Rebalance the tree after a deletion
+		retNode = persistentSkew(retNode)
+		if retNode.greater != nil {
+			retNode.greater = persistentSkew(retNode.greater)
+			subNode := retNode.greater
+			if subNode.greater != nil {
+				subGreater := persistentSkew(subNode.greater)
+				if subGreater != subNode.greater {
+					subNode = subNode.clone()
+					subNode.greater = subGreater
+					retNode.greater = subNode
+				}
+			}
+		}
+		retNode = persistentSplit(retNode)
+		if retNode.greater != nil {
+			retNode.greater = persistentSplit(retNode.greater)
+		}
+	}
+
+	return retNode, removed
+}
+
+func (tree *PersistentTreeMap[K, V]) Get(key K) (V, bool) {
+	var retValue V
+	var retFlag bool = false
+	node := tree.root
+	for node != nil {
+		dir := tree.cmpFn(key, node.key)
+		if dir < 0 {
+			node = node.less
+		} else if dir > 0 {
+			node = node.greater
+		} else {
+			retValue = node.value
+			retFlag = true
+			break
+		}
+	}
+	return retValue, retFlag
+}
+
+func (tree *PersistentTreeMap[K, V]) GetFirstKey() (K, bool) {
+	var retKey K
+	var retFlag bool = false
+	node := tree.root
+	if node != nil {
+		for node.less != nil {
+			node = node.less
+		}
+		retKey = node.key
+		retFlag = true
+	}
+	return retKey, retFlag
+}
+
+func (tree *PersistentTreeMap[K, V]) GetLastKey() (K, bool) {
+	var retKey K
+	var retFlag bool = false
+	node := tree.root
+	if node != nil {
+		for node.greater != nil {
+			node = node.greater
+		}
+		retKey = node.key
+		retFlag = true
+	}
+	return retKey, retFlag
+}
+
+func (tree *PersistentTreeMap[K, V]) GetSize() int {
+	return tree.size
+}
+
+// Rotates a left horizontal link to the right
+//
+// The node and its subtrees are not modified, rotated nodes are replaced by copies
+func persistentSkew[K, V any](node *persistentNode[K, V]) *persistentNode[K, V] {
+	rotNode := node
+	if node.less != nil && node.level == node.less.level {
+		subNode := node.clone()
+		rotNode = node.less.clone()
+		subNode.less = rotNode.greater
+		rotNode.greater = subNode
+	}
+	return rotNode
+}
+
+// Rotates two consecutive right horizontal links to the left and raises
+// the level of the middle node
+//
+// The node and its subtrees are not modified, rotated nodes are replaced by copies
+func persistentSplit[K, V any](node *persistentNode[K, V]) *persistentNode[K, V] {
+	rotNode := node
+	if node.greater != nil && node.greater.greater != nil &&
+		node.level == node.greater.greater.level {
+		subNode := node.clone()
+		rotNode = node.greater.clone()
+		subNode.greater = rotNode.less
+		rotNode.less = subNode
+		rotNode.level++
+	}
+	return rotNode
+}
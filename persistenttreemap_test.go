@@ -0,0 +1,110 @@
+// PersistentTreeMap tests -- version isolation checked against copies of a Go map
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+import (
+	"cmp"
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Checks that a version of the map contains exactly the entries of the
+// reference map in ascending key order
+func checkPersistentTreeMap(t *testing.T, tree *PersistentTreeMap[int, int], ref map[int]int) {
+	t.Helper()
+	if tree.GetSize() != len(ref) {
+		t.Fatalf("size is %d, expected %d", tree.GetSize(), len(ref))
+	}
+	refKeys := slices.Sorted(maps.Keys(ref))
+	idx := 0
+	for key, value := range tree.All() {
+		if idx >= len(refKeys) || key != refKeys[idx] || value != ref[key] {
+			t.Fatalf("entry %d is %d=%d, which is not in the reference map", idx, key, value)
+		}
+		idx++
+	}
+	if idx != len(refKeys) {
+		t.Fatalf("iterated %d entries, expected %d", idx, len(refKeys))
+	}
+	for _, key := range refKeys {
+		if value, flag := tree.Get(key); !flag || value != ref[key] {
+			t.Fatalf("get %d returned %d, %t, expected %d", key, value, flag, ref[key])
+		}
+	}
+	if len(refKeys) > 0 {
+		firstKey, _ := tree.GetFirstKey()
+		lastKey, _ := tree.GetLastKey()
+		if firstKey != refKeys[0] || lastKey != refKeys[len(refKeys)-1] {
+			t.Fatalf("first and last keys are %d and %d", firstKey, lastKey)
+		}
+	}
+}
+
+func TestPersistentTreeMapVersions(t *testing.T) {
+	tests := []struct {
+		name       string
+		keyRange   int
+		opCount    int
+		removeRate int
+	}{
+		{"inserts", 1000, 600, 0},
+		{"dense", 50, 600, 2},
+		{"sparse", 100000, 600, 3},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			random := rand.New(rand.NewSource(5))
+			versions := []*PersistentTreeMap[int, int]{NewPersistentTreeMapOf[int, int](cmp.Compare[int])}
+			refs := []map[int]int{{}}
+			for idx := 0; idx < test.opCount; idx++ {
+				// Each operation is applied to a random earlier version
+				base := random.Intn(len(versions))
+				tree := versions[base]
+				ref := maps.Clone(refs[base])
+				key := random.Intn(test.keyRange)
+				if test.removeRate > 0 && random.Intn(test.removeRate) == 0 {
+					tree = tree.Remove(key)
+					delete(ref, key)
+				} else {
+					tree = tree.Insert(key, idx)
+					ref[key] = idx
+				}
+				versions = append(versions, tree)
+				refs = append(refs, ref)
+				checkPersistentTreeMap(t, tree, ref)
+			}
+			for idx, tree := range versions {
+				checkPersistentTreeMap(t, tree, refs[idx])
+			}
+		})
+	}
+}
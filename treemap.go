@@ -29,20 +29,74 @@
 // EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
 package dsaext
 
-type treeNode struct {
-	key     interface{}
-	value   interface{}
-	parent  *treeNode
-	less    *treeNode
-	greater *treeNode
+import (
+	"iter"
+)
+
+type treeNode[K, V any] struct {
+	key     K
+	value   V
+	parent  *treeNode[K, V]
+	less    *treeNode[K, V]
+	greater *treeNode[K, V]
 	level   int
+	// Number of nodes in the subtree rooted at this node
+	count int
+}
+
+func newtreeNode[K, V any](key K, value V, parent, less, greater *treeNode[K, V]) *treeNode[K, V] {
+	return &treeNode[K, V]{key, value, parent, less, greater, 1, 1}
+}
+
+func (node *treeNode[K, V]) updateCount() {
+	node.count = 1 + node.less.subtreeCount() + node.greater.subtreeCount()
+}
+
+func (node *treeNode[K, V]) subtreeCount() int {
+	var count int = 0
+	if node != nil {
+		count = node.count
+	}
+	return count
+}
+
+// Updates a node's subtree size and augmented data after changes of its subtrees
+func (tree *TreeMap[K, V]) updateNode(node *treeNode[K, V]) {
+	node.updateCount()
+	if tree.augmentFn != nil {
+		tree.augmentFn(node)
+	}
 }
 
-func newtreeNode(key, value interface{}, parent, less, greater *treeNode) *treeNode {
-	return &treeNode{key, value, parent, less, greater, 1}
+// Updates the augmented data on the path from a node with a changed value
+// to the tree's root
+func (tree *TreeMap[K, V]) valueChanged(node *treeNode[K, V]) {
+	if tree.augmentFn != nil {
+		for node != nil {
+			tree.augmentFn(node)
+			node = node.parent
+		}
+	}
 }
 
-func (node *treeNode) successor() *treeNode {
+// Updates the augmented data of all nodes of a subtree
+func (tree *TreeMap[K, V]) augmentSubtree(node *treeNode[K, V]) {
+	if tree.augmentFn != nil && node != nil {
+		tree.augmentSubtree(node.less)
+		tree.augmentSubtree(node.greater)
+		tree.augmentFn(node)
+	}
+}
+
+func (node *treeNode[K, V]) nodeLevel() int {
+	var level int = 0
+	if node != nil {
+		level = node.level
+	}
+	return level
+}
+
+func (node *treeNode[K, V]) successor() *treeNode[K, V] {
 	retNode := node
 	for retNode != nil {
 		if retNode.greater != nil {
@@ -68,7 +122,7 @@ func (node *treeNode) successor() *treeNode {
 	return retNode
 }
 
-func (node *treeNode) predecessor() *treeNode {
+func (node *treeNode[K, V]) predecessor() *treeNode[K, V] {
 	retNode := node
 	for retNode != nil {
 		if retNode.less != nil {
@@ -82,7 +136,7 @@ func (node *treeNode) predecessor() *treeNode {
 		} else {
 			// Find a parent node with a lower value
 			for retNode.parent != nil {
-				if retNode.parent.less == retNode {
+				if retNode.parent.greater == retNode {
 					break
 				}
 				retNode = retNode.parent
@@ -94,34 +148,127 @@ func (node *treeNode) predecessor() *treeNode {
 	return retNode
 }
 
-type TreeMapIterator struct {
-	nextNode *treeNode
+func (node *treeNode[K, V]) entry() (K, V, bool) {
+	var key K
+	var value V
+	flag := false
+	if node != nil {
+		key = node.key
+		value = node.value
+		flag = true
+	}
+	return key, value, flag
+}
+
+type TreeMapIterator[K, V any] struct {
+	nextNode *treeNode[K, V]
+	upper    Bound[K]
+	cmpFn    compareFn[K]
+}
+
+func newTreeMapIterator[K, V any](node *treeNode[K, V], upper Bound[K], cmpFn compareFn[K]) *TreeMapIterator[K, V] {
+	iter := &TreeMapIterator[K, V]{node, upper, cmpFn}
+	iter.checkBound()
+	return iter
 }
 
-func (iter *TreeMapIterator) Next() (interface{}, interface{}, bool) {
-	var key interface{} = nil
-	var value interface{} = nil
+func (iter *TreeMapIterator[K, V]) Next() (K, V, bool) {
+	var key K
+	var value V
 	flag := false
 	if iter.nextNode != nil {
 		key = iter.nextNode.key
 		value = iter.nextNode.value
 		flag = true
 		iter.nextNode = iter.nextNode.successor()
+		iter.checkBound()
 	}
 	return key, value, flag
 }
 
-type TreeMap struct {
-	root  *treeNode
+func (iter *TreeMapIterator[K, V]) checkBound() {
+	if iter.nextNode != nil && !iter.upper.admitsBelow(iter.nextNode.key, iter.cmpFn) {
+		// Reached the end of the range
+		iter.nextNode = nil
+	}
+}
+
+type TreeMap[K, V any] struct {
+	root  *treeNode[K, V]
 	size  int
-	cmpFn compareFn
+	cmpFn compareFn[K]
+	// Node allocator of pooled maps, nil if nodes are allocated individually
+	arena *treeNodeArena[K, V]
+	// Function that updates augmented data of a node from the node's entry and
+	// from its subtrees, nil if the map is not augmented
+	augmentFn func(node *treeNode[K, V])
+	settings  mapSettings[K, V]
+}
+
+// Creates a TreeMap with keys and values of type interface{}
+func NewTreeMap(cmpFn compareFn[interface{}]) *TreeMap[interface{}, interface{}] {
+	return NewTreeMapOf[interface{}, interface{}](cmpFn)
+}
+
+// Creates a TreeMap with keys of type K and values of type V
+func NewTreeMapOf[K, V any](cmpFn compareFn[K]) *TreeMap[K, V] {
+	return &TreeMap[K, V]{nil, 0, cmpFn, nil, nil, mapSettings[K, V]{}}
+}
+
+func (tree *TreeMap[K, V]) Iterator() *TreeMapIterator[K, V] {
+	return newTreeMapIterator(tree.firstNode(), Unbounded[K](), tree.cmpFn)
+}
+
+// Returns an iterator over the entries with keys within the specified bounds
+func (tree *TreeMap[K, V]) Range(lower, upper Bound[K]) *TreeMapIterator[K, V] {
+	return newTreeMapIterator(tree.lowerBoundNode(lower), upper, tree.cmpFn)
+}
+
+// Returns a sequence of all entries in ascending key order
+func (tree *TreeMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := tree.firstNode(); node != nil; node = node.successor() {
+			if !yield(node.key, node.value) {
+				break
+			}
+		}
+	}
 }
 
-func NewTreeMap(cmpFn compareFn) *TreeMap {
-	return &TreeMap{nil, 0, cmpFn}
+// Returns a sequence of all entries in descending key order
+func (tree *TreeMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := tree.lastNode(); node != nil; node = node.predecessor() {
+			if !yield(node.key, node.value) {
+				break
+			}
+		}
+	}
 }
 
-func (tree *TreeMap) Iterator() *TreeMapIterator {
+// Returns a sequence of all keys in ascending order
+func (tree *TreeMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for node := tree.firstNode(); node != nil; node = node.successor() {
+			if !yield(node.key) {
+				break
+			}
+		}
+	}
+}
+
+// Returns a sequence of all values in ascending order of their keys
+func (tree *TreeMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for node := tree.firstNode(); node != nil; node = node.successor() {
+			if !yield(node.value) {
+				break
+			}
+		}
+	}
+}
+
+func (tree *TreeMap[K, V]) firstNode() *treeNode[K, V] {
 	node := tree.root
 	if node != nil {
 		// Find the item with the lowest key
@@ -129,172 +276,413 @@ func (tree *TreeMap) Iterator() *TreeMapIterator {
 			node = node.less
 		}
 	}
-	return &TreeMapIterator{node}
+	return node
 }
 
-func (tree *TreeMap) Insert(key, value interface{}) {
-	if tree.root == nil {
-		// Insert at the tree's root
-		tree.root = newtreeNode(key, value, nil, nil, nil)
-		tree.size++
+func (tree *TreeMap[K, V]) lastNode() *treeNode[K, V] {
+	node := tree.root
+	if node != nil {
+		// Find the item with the highest key
+		for node.greater != nil {
+			node = node.greater
+		}
+	}
+	return node
+}
+
+// Returns the node with the lowest key that is within the lower bound
+func (tree *TreeMap[K, V]) lowerBoundNode(lower Bound[K]) *treeNode[K, V] {
+	var node *treeNode[K, V] = nil
+	switch lower.kind {
+	case inclusiveKind:
+		node = tree.ceilingNode(lower.key, true)
+	case exclusiveKind:
+		node = tree.ceilingNode(lower.key, false)
+	default:
+		node = tree.firstNode()
+	}
+	return node
+}
+
+// Inserts or updates the entry with the specified key
+//
+// Returns the previous value and a flag that indicates whether the key was new
+func (tree *TreeMap[K, V]) Insert(key K, value V) (V, bool) {
+	var prevValue V
+	node, dir := tree.insertWalk(key)
+	isNew := node == nil || dir != 0
+	if isNew {
+		tree.insertNode(node, dir, key, value)
+	} else {
+		// Update existing item
+		// No rebalancing is required
+		prevValue = node.value
+		node.value = value
+		tree.valueChanged(node)
+	}
+	return prevValue, isNew
+}
+
+// Inserts an entry unless the map already contains the key
+//
+// Returns the value that the map contains for the key after the operation
+// and a flag that indicates whether the entry was inserted
+func (tree *TreeMap[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	retValue := value
+	node, dir := tree.insertWalk(key)
+	isNew := node == nil || dir != 0
+	if isNew {
+		tree.insertNode(node, dir, key, value)
 	} else {
-		// Insert below the tree's root
-		tree.root = tree.insertWalk(tree.root, key, value)
+		retValue = node.value
 	}
+	return retValue, isNew
 }
 
-func (tree *TreeMap) insertWalk(node *treeNode, key, value interface{}) *treeNode {
-	retNode := node
-	dir := tree.cmpFn(key, node.key)
-	if dir < 0 {
-		if node.less == nil {
-			node.less = newtreeNode(key, value, node, nil, nil)
-			tree.size++
+// Replaces the value of an existing entry
+//
+// Returns the previous value and a flag that indicates whether the map
+// contained the key
+func (tree *TreeMap[K, V]) Replace(key K, value V) (V, bool) {
+	var prevValue V
+	node, found := tree.findNode(key)
+	if found {
+		prevValue = node.value
+		node.value = value
+		tree.valueChanged(node)
+	}
+	return prevValue, found
+}
+
+// Computes the new value for a key from its current value
+//
+// The compute function is called with the current value and a flag that
+// indicates whether the map contains the key, and returns the new value and
+// a flag that indicates whether the entry should be kept. If the entry is not
+// kept, it is removed from the map or not inserted.
+// Returns the new value and the flag returned by the compute function.
+func (tree *TreeMap[K, V]) Compute(key K, computeFn func(oldValue V, exists bool) (V, bool)) (V, bool) {
+	var oldValue V
+	node, dir := tree.insertWalk(key)
+	exists := node != nil && dir == 0
+	if exists {
+		oldValue = node.value
+	}
+	newValue, keep := computeFn(oldValue, exists)
+	if keep {
+		if exists {
+			node.value = newValue
+			tree.valueChanged(node)
 		} else {
-			node.less = tree.insertWalk(node.less, key, value)
+			tree.insertNode(node, dir, key, newValue)
 		}
-		// Rebalance the tree after an insertion
-		retNode = tree.skew(retNode)
-		retNode = tree.split(retNode)
-	} else if dir > 0 {
-		if node.greater == nil {
-			node.greater = newtreeNode(key, value, node, nil, nil)
-			tree.size++
+	} else if exists {
+		tree.removeNode(node)
+	}
+	return newValue, keep
+}
+
+// Inserts the value if the map does not contain the key, otherwise
+// replaces the current value with the result of the merge function
+//
+// Returns the value that the map contains for the key after the operation
+func (tree *TreeMap[K, V]) Merge(key K, value V, mergeFn func(oldValue, value V) V) V {
+	retValue := value
+	node, dir := tree.insertWalk(key)
+	if node != nil && dir == 0 {
+		retValue = mergeFn(node.value, value)
+		node.value = retValue
+		tree.valueChanged(node)
+	} else {
+		tree.insertNode(node, dir, key, value)
+	}
+	return retValue
+}
+
+// Finds the node with the specified key, or the node below which a node with the
+// specified key would be inserted
+//
+// Returns the node and the result of comparing the key to the node's key,
+// which is 0 if the node has the specified key. The node is nil if the tree is empty.
+func (tree *TreeMap[K, V]) insertWalk(key K) (*treeNode[K, V], int) {
+	node := tree.root
+	var dir int = 0
+	for node != nil {
+		dir = tree.cmpFn(key, node.key)
+		if dir < 0 && node.less != nil {
+			node = node.less
+		} else if dir > 0 && node.greater != nil {
+			node = node.greater
 		} else {
-			node.greater = tree.insertWalk(node.greater, key, value)
+			break
 		}
-		// Rebalance the tree after an insertion
-		retNode = tree.skew(retNode)
-		retNode = tree.split(retNode)
+	}
+	return node, dir
+}
+
+// Inserts a new node below the parent node found by insertWalk and rebalances the tree
+func (tree *TreeMap[K, V]) insertNode(parent *treeNode[K, V], dir int, key K, value V) {
+	node := tree.allocNode(key, value, parent)
+	if parent == nil {
+		// Insert at the tree's root
+		tree.root = node
+	} else if dir < 0 {
+		parent.less = node
 	} else {
-		// Update existing item
-		// No rebalancing is required
-		node.value = value
+		parent.greater = node
 	}
-	return retNode
+	tree.size++
+	tree.updateNode(node)
+	tree.insertRebalance(parent)
 }
 
-func (tree *TreeMap) Remove(key interface{}) {
+// Rebalances the tree after an insertion, starting at the parent node of
+// the inserted node and following the parent links up to the tree's root
+func (tree *TreeMap[K, V]) insertRebalance(node *treeNode[K, V]) {
+	for node != nil {
+		tree.updateNode(node)
+		// skew and split update the link from the parent node
+		node = tree.skew(node)
+		node = tree.split(node)
+		node = node.parent
+	}
+}
+
+func (tree *TreeMap[K, V]) Remove(key K) {
 	if tree.root != nil {
-		tree.root = tree.removeWalk(tree.root, key)
+		tree.removeWalk(key)
 	}
 }
 
-func (tree *TreeMap) removeWalk(node *treeNode, key interface{}) *treeNode {
-	retNode := node
-	dir := tree.cmpFn(key, node.key)
-	if dir < 0 {
-		if node.less != nil {
-			node.less = tree.removeWalk(node.less, key)
-			if node.less != nil {
-				node.less.parent = node
-			}
+// Removes all entries for which the predicate returns true and returns the
+// number of entries that were removed
+//
+// The remaining entries are relinked into a balanced tree in a single pass.
+func (tree *TreeMap[K, V]) RemoveIf(predicate func(key K, value V) bool) int {
+	keepNodes := make([]*treeNode[K, V], 0, tree.size)
+	var delNodes []*treeNode[K, V] = nil
+	for node := tree.firstNode(); node != nil; node = node.successor() {
+		if !predicate(node.key, node.value) {
+			keepNodes = append(keepNodes, node)
+		} else if tree.arena != nil {
+			delNodes = append(delNodes, node)
 		}
-	} else if dir > 0 {
-		if node.greater != nil {
-			node.greater = tree.removeWalk(node.greater, key)
-			if node.greater != nil {
-				node.greater.parent = node
-			}
+	}
+	count := tree.size - len(keepNodes)
+	if count > 0 {
+		tree.root = linkSortedNodes(keepNodes, nil)
+		tree.size = len(keepNodes)
+		tree.augmentSubtree(tree.root)
+		for _, node := range delNodes {
+			tree.freeNode(node)
 		}
-	} else {
-		if node.less != nil {
+	}
+	return count
+}
+
+func (tree *TreeMap[K, V]) removeWalk(key K) {
+	node, found := tree.findNode(key)
+	if found {
+		tree.removeNode(node)
+	}
+}
+
+// Removes a node's entry from the tree
+//
+// Unless the node is a leaf, the node's entry is replaced by the entry of its
+// predecessor or successor, until a leaf is reached that is unlinked from the tree.
+func (tree *TreeMap[K, V]) removeNode(node *treeNode[K, V]) {
+	delNode := node
+	for delNode.less != nil || delNode.greater != nil {
+		var subNode *treeNode[K, V]
+		if delNode.less != nil {
 			// Find predecessor node
-			delNode := node.less
-			for delNode.greater != nil {
-				delNode = delNode.greater
+			subNode = delNode.less
+			for subNode.greater != nil {
+				subNode = subNode.greater
 			}
-			// Copy value and remove leaf
-			node.key = delNode.key
-			node.value = delNode.value
-			node.less = tree.removeWalk(node.less, delNode.key)
-			if node.less != nil {
-				node.less.parent = node
-			}
-		} else if node.greater != nil {
+		} else {
 			// Find successor node
-			delNode := node.greater
-			for delNode.less != nil {
-				delNode = delNode.less
+			subNode = delNode.greater
+			for subNode.less != nil {
+				subNode = subNode.less
 			}
-			// Copy value and remove leaf
-			node.key = delNode.key
-			node.value = delNode.value
-			node.greater = tree.removeWalk(node.greater, delNode.key)
-			if node.greater != nil {
-				node.greater.parent = node
-			}
-		} else {
-			retNode = nil
-			tree.size--
 		}
+		// Copy value and continue with the predecessor or successor
+		delNode.key = subNode.key
+		delNode.value = subNode.value
+		delNode = subNode
 	}
 
-	if retNode != nil {
-		// Adjust the balance level
-		if retNode.less != nil || retNode.greater != nil {
-			var maxLevel int
-			if retNode.less != nil {
-				maxLevel = retNode.less.level
-			}
-			if retNode.greater != nil && retNode.greater.level < maxLevel {
-				maxLevel = retNode.greater.level
-			}
-			maxLevel++
-			if retNode.level >= maxLevel {
-				retNode.level = maxLevel
-				if retNode.greater != nil && retNode.greater.level >= maxLevel {
-					retNode.greater.level = maxLevel
-				}
-			}
-		}
+	// Remove leaf
+	parent := delNode.parent
+	if parent == nil {
+		tree.root = nil
+	} else if parent.less == delNode {
+		parent.less = nil
+	} else {
+		parent.greater = nil
+	}
+	tree.freeNode(delNode)
+	tree.size--
 
-		// Rebalance the tree after a deletion
-		retNode = tree.skew(retNode)
-		if retNode.greater != nil {
-			retNode.greater = tree.skew(retNode.greater)
-			subNode := retNode.greater
-			if subNode.greater != nil {
-				subNode.greater = tree.skew(subNode.greater)
-			}
+	// Rebalance the tree on the path back to the tree's root
+	for parent != nil {
+		parent = tree.removeRebalance(parent)
+		parent = parent.parent
+	}
+}
+
+// Returns the entry with the lowest key
+func (tree *TreeMap[K, V]) FirstEntry() (K, V, bool) {
+	return tree.firstNode().entry()
+}
+
+// Returns the entry with the highest key
+func (tree *TreeMap[K, V]) LastEntry() (K, V, bool) {
+	return tree.lastNode().entry()
+}
+
+// Removes the entry with the lowest key and returns it
+func (tree *TreeMap[K, V]) PollFirst() (K, V, bool) {
+	node := tree.firstNode()
+	key, value, flag := node.entry()
+	if node != nil {
+		tree.removeNode(node)
+	}
+	return key, value, flag
+}
+
+// Removes the entry with the highest key and returns it
+func (tree *TreeMap[K, V]) PollLast() (K, V, bool) {
+	node := tree.lastNode()
+	key, value, flag := node.entry()
+	if node != nil {
+		tree.removeNode(node)
+	}
+	return key, value, flag
+}
+
+// Restores the balance of a node on the path to a removed node
+func (tree *TreeMap[K, V]) removeRebalance(node *treeNode[K, V]) *treeNode[K, V] {
+	retNode := node
+	tree.updateNode(retNode)
+
+	// Adjust the balance level
+	// The level of a missing subtree is 0
+	maxLevel := retNode.less.nodeLevel()
+	if retNode.greater.nodeLevel() < maxLevel {
+		maxLevel = retNode.greater.nodeLevel()
+	}
+	maxLevel++
+	if retNode.level >= maxLevel {
+		retNode.level = maxLevel
+		if retNode.greater != nil && retNode.greater.level >= maxLevel {
+			retNode.greater.level = maxLevel
 		}
-		retNode = tree.split(retNode)
-		if retNode.greater != nil {
-			retNode.greater = tree.split(retNode.greater)
+	}
+
+	// Rebalance the tree after a deletion
+	retNode = tree.skew(retNode)
+	if retNode.greater != nil {
+		retNode.greater = tree.skew(retNode.greater)
+		subNode := retNode.greater
+		if subNode.greater != nil {
+			subNode.greater = tree.skew(subNode.greater)
 		}
 	}
+	retNode = tree.split(retNode)
+	if retNode.greater != nil {
+		retNode.greater = tree.split(retNode.greater)
+	}
 
 	return retNode
 }
 
-func (tree *TreeMap) Get(key interface{}) (interface{}, bool) {
-	var retValue interface{} = nil
-	retNode, retFlag := tree.getWalk(tree.root, key)
+func (tree *TreeMap[K, V]) Get(key K) (V, bool) {
+	var retValue V
+	node, retFlag := tree.findNode(key)
 	if retFlag {
-		retValue = retNode.value
+		retValue = node.value
 	}
 	return retValue, retFlag
 }
 
-func (tree *TreeMap) getWalk(node *treeNode, key interface{}) (*treeNode, bool) {
-	var retNode *treeNode = nil
-	var retFlag bool = false
-	if node != nil {
+func (tree *TreeMap[K, V]) findNode(key K) (*treeNode[K, V], bool) {
+	node := tree.root
+	for node != nil {
 		dir := tree.cmpFn(key, node.key)
 		if dir < 0 {
-			retNode, retFlag = tree.getWalk(node.less, key)
+			node = node.less
 		} else if dir > 0 {
-			retNode, retFlag = tree.getWalk(node.greater, key)
+			node = node.greater
 		} else {
+			break
+		}
+	}
+	return node, node != nil
+}
+
+// Returns the entry with the greatest key less than or equal to the specified key
+func (tree *TreeMap[K, V]) Floor(key K) (K, V, bool) {
+	return tree.floorNode(key, true).entry()
+}
+
+// Returns the entry with the smallest key greater than or equal to the specified key
+func (tree *TreeMap[K, V]) Ceiling(key K) (K, V, bool) {
+	return tree.ceilingNode(key, true).entry()
+}
+
+// Returns the entry with the greatest key less than the specified key
+func (tree *TreeMap[K, V]) Lower(key K) (K, V, bool) {
+	return tree.floorNode(key, false).entry()
+}
+
+// Returns the entry with the smallest key greater than the specified key
+func (tree *TreeMap[K, V]) Higher(key K) (K, V, bool) {
+	return tree.ceilingNode(key, false).entry()
+}
+
+func (tree *TreeMap[K, V]) floorNode(key K, inclusive bool) *treeNode[K, V] {
+	var retNode *treeNode[K, V] = nil
+	node := tree.root
+	for node != nil {
+		dir := tree.cmpFn(key, node.key)
+		if dir > 0 || (dir == 0 && inclusive) {
+			// Candidate node, continue looking for a greater key
 			retNode = node
-			retFlag = true
+			if dir == 0 {
+				break
+			}
+			node = node.greater
+		} else {
+			node = node.less
+		}
+	}
+	return retNode
+}
+
+func (tree *TreeMap[K, V]) ceilingNode(key K, inclusive bool) *treeNode[K, V] {
+	var retNode *treeNode[K, V] = nil
+	node := tree.root
+	for node != nil {
+		dir := tree.cmpFn(key, node.key)
+		if dir < 0 || (dir == 0 && inclusive) {
+			// Candidate node, continue looking for a lower key
+			retNode = node
+			if dir == 0 {
+				break
+			}
+			node = node.less
+		} else {
+			node = node.greater
 		}
 	}
-	return retNode, retFlag
+	return retNode
 }
 
-func (tree *TreeMap) GetFirstKey() (interface{}, bool) {
-	var retKey interface{} = nil
+func (tree *TreeMap[K, V]) GetFirstKey() (K, bool) {
+	var retKey K
 	var retFlag bool = false
 	node := tree.root
 	if node != nil {
@@ -307,8 +695,8 @@ func (tree *TreeMap) GetFirstKey() (interface{}, bool) {
 	return retKey, retFlag
 }
 
-func (tree *TreeMap) GetLastKey() (interface{}, bool) {
-	var retKey interface{} = nil
+func (tree *TreeMap[K, V]) GetLastKey() (K, bool) {
+	var retKey K
 	var retFlag bool = false
 	node := tree.root
 	if node != nil {
@@ -321,11 +709,86 @@ func (tree *TreeMap) GetLastKey() (interface{}, bool) {
 	return retKey, retFlag
 }
 
-func (tree *TreeMap) GetSize() int {
+// Returns the entry with the specified zero-based index in ascending key order
+func (tree *TreeMap[K, V]) Select(index int) (K, V, bool) {
+	var retNode *treeNode[K, V] = nil
+	node := tree.root
+	for node != nil {
+		lessCount := node.less.subtreeCount()
+		if index < lessCount {
+			node = node.less
+		} else if index > lessCount {
+			index -= lessCount + 1
+			node = node.greater
+		} else {
+			retNode = node
+			break
+		}
+	}
+	return retNode.entry()
+}
+
+// Returns the number of keys that are less than the specified key and
+// a flag that indicates whether the key is contained in the map
+func (tree *TreeMap[K, V]) Rank(key K) (int, bool) {
+	var rank int = 0
+	var retFlag bool = false
+	node := tree.root
+	for node != nil {
+		dir := tree.cmpFn(key, node.key)
+		if dir < 0 {
+			node = node.less
+		} else if dir > 0 {
+			rank += node.less.subtreeCount() + 1
+			node = node.greater
+		} else {
+			rank += node.less.subtreeCount()
+			retFlag = true
+			break
+		}
+	}
+	return rank, retFlag
+}
+
+// Returns the number of entries with keys within the specified bounds
+func (tree *TreeMap[K, V]) CountRange(lower, upper Bound[K]) int {
+	upperCount := tree.size
+	if upper.kind != unboundedKind {
+		upperCount = tree.countBelow(upper.key, upper.kind == inclusiveKind)
+	}
+	lowerCount := 0
+	if lower.kind != unboundedKind {
+		lowerCount = tree.countBelow(lower.key, lower.kind == exclusiveKind)
+	}
+	var count int = 0
+	if upperCount > lowerCount {
+		count = upperCount - lowerCount
+	}
+	return count
+}
+
+// Returns the number of keys that are less than the specified key, or less
+// than or equal to the specified key if inclusive is set
+func (tree *TreeMap[K, V]) countBelow(key K, inclusive bool) int {
+	var count int = 0
+	node := tree.root
+	for node != nil {
+		dir := tree.cmpFn(key, node.key)
+		if dir > 0 || (dir == 0 && inclusive) {
+			count += node.less.subtreeCount() + 1
+			node = node.greater
+		} else {
+			node = node.less
+		}
+	}
+	return count
+}
+
+func (tree *TreeMap[K, V]) GetSize() int {
 	return tree.size
 }
 
-func (tree *TreeMap) skew(node *treeNode) *treeNode {
+func (tree *TreeMap[K, V]) skew(node *treeNode[K, V]) *treeNode[K, V] {
 	rotNode := node
 	if node.less != nil && node.level == node.less.level {
 		rotNode = node.less
@@ -345,11 +808,13 @@ func (tree *TreeMap) skew(node *treeNode) *treeNode {
 		}
 		rotNode.greater = node
 		node.parent = rotNode
+		tree.updateNode(node)
+		tree.updateNode(rotNode)
 	}
 	return rotNode
 }
 
-func (tree *TreeMap) split(node *treeNode) *treeNode {
+func (tree *TreeMap[K, V]) split(node *treeNode[K, V]) *treeNode[K, V] {
 	rotNode := node
 	if node.greater != nil && node.greater.greater != nil &&
 		node.level == node.greater.greater.level {
@@ -371,6 +836,8 @@ func (tree *TreeMap) split(node *treeNode) *treeNode {
 		rotNode.less = node
 		node.parent = rotNode
 		rotNode.level++
+		tree.updateNode(node)
+		tree.updateNode(rotNode)
 	}
 	return rotNode
 }
@@ -0,0 +1,342 @@
+// TreeMap tests -- randomized operations checked against a Go map, and benchmarks on 100000 random int keys
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+import (
+	"cmp"
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+const benchmarkKeyCount = 100000
+
+// Returns the same pseudo-random keys on every run, so that results are comparable
+func benchmarkKeys() []int {
+	random := rand.New(rand.NewSource(42))
+	keys := make([]int, benchmarkKeyCount)
+	for idx := range keys {
+		keys[idx] = random.Int()
+	}
+	return keys
+}
+
+func BenchmarkGet(b *testing.B) {
+	keys := benchmarkKeys()
+	tree := NewTreeMapOf[int, int](cmp.Compare[int])
+	for _, key := range keys {
+		tree.Insert(key, key)
+	}
+	b.ResetTimer()
+	for idx := 0; idx < b.N; idx++ {
+		tree.Get(keys[idx%len(keys)])
+	}
+}
+
+// Inserts the keys, then keeps updating the entries of the existing keys
+func BenchmarkInsert(b *testing.B) {
+	keys := benchmarkKeys()
+	tree := NewTreeMapOf[int, int](cmp.Compare[int])
+	b.ResetTimer()
+	for idx := 0; idx < b.N; idx++ {
+		tree.Insert(keys[idx%len(keys)], idx)
+	}
+}
+
+// Swaps keys in and out of a map that holds half of the keys, doing two
+// insertions and two removals per iteration
+func BenchmarkInsertRemove(b *testing.B) {
+	keys := benchmarkKeys()
+	half := len(keys) / 2
+	tree := NewTreeMapOf[int, int](cmp.Compare[int])
+	for _, key := range keys[:half] {
+		tree.Insert(key, key)
+	}
+	b.ResetTimer()
+	for idx := 0; idx < b.N; idx++ {
+		tree.Insert(keys[half+idx%half], idx)
+		tree.Remove(keys[idx%half])
+		tree.Insert(keys[idx%half], idx)
+		tree.Remove(keys[half+idx%half])
+	}
+}
+
+// Validates the tree and checks that it contains exactly the entries of the
+// reference map in ascending key order
+func checkTreeMap(t *testing.T, tree *TreeMap[int, int], ref map[int]int) {
+	t.Helper()
+	if err := tree.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if tree.GetSize() != len(ref) {
+		t.Fatalf("size is %d, expected %d", tree.GetSize(), len(ref))
+	}
+	refKeys := slices.Sorted(maps.Keys(ref))
+	idx := 0
+	for key, value := range tree.All() {
+		if idx >= len(refKeys) || key != refKeys[idx] || value != ref[key] {
+			t.Fatalf("entry %d is %d=%d, expected %d=%d", idx, key, value, refKeys[idx], ref[refKeys[idx]])
+		}
+		idx++
+	}
+	if idx != len(refKeys) {
+		t.Fatalf("iterated %d entries, expected %d", idx, len(refKeys))
+	}
+}
+
+// Creates a map with random entries and a reference map with the same entries
+func randomTreeMap(random *rand.Rand, slabSize, count, keyRange int) (*TreeMap[int, int], map[int]int) {
+	var tree *TreeMap[int, int] = nil
+	if slabSize > 0 {
+		tree = NewPooledTreeMapOf[int, int](cmp.Compare[int], slabSize)
+	} else {
+		tree = NewTreeMapOf[int, int](cmp.Compare[int])
+	}
+	ref := make(map[int]int)
+	for idx := 0; idx < count; idx++ {
+		key := random.Intn(keyRange)
+		tree.Insert(key, idx)
+		ref[key] = idx
+	}
+	return tree, ref
+}
+
+// Checks the first and last entry returned by PollFirst or PollLast against
+// the reference map and removes the entry from the reference map
+func checkPoll(t *testing.T, ref map[int]int, key, value int, flag, last bool) {
+	t.Helper()
+	if flag != (len(ref) > 0) {
+		t.Fatalf("poll flag is %t with %d entries", flag, len(ref))
+	}
+	if flag {
+		expectedKey := slices.Min(slices.Collect(maps.Keys(ref)))
+		if last {
+			expectedKey = slices.Max(slices.Collect(maps.Keys(ref)))
+		}
+		if key != expectedKey || value != ref[key] {
+			t.Fatalf("polled %d=%d, expected %d=%d", key, value, expectedKey, ref[expectedKey])
+		}
+		delete(ref, key)
+	}
+}
+
+func TestTreeMapRandomOperations(t *testing.T) {
+	tests := []struct {
+		name     string
+		slabSize int
+		keyRange int
+		opCount  int
+	}{
+		{"dense", 0, 64, 4000},
+		{"sparse", 0, 100000, 4000},
+		{"pooled dense", 16, 64, 4000},
+		{"pooled sparse", 7, 100000, 4000},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			random := rand.New(rand.NewSource(1))
+			tree, ref := randomTreeMap(random, test.slabSize, 0, 1)
+			for idx := 0; idx < test.opCount; idx++ {
+				key := random.Intn(test.keyRange)
+				switch random.Intn(6) {
+				case 0, 1:
+					prevValue, isNew := tree.Insert(key, idx)
+					refValue, refExists := ref[key]
+					if isNew == refExists || prevValue != refValue {
+						t.Fatalf("insert %d returned %d, %t, expected %d, %t", key, prevValue, isNew, refValue, !refExists)
+					}
+					ref[key] = idx
+				case 2:
+					tree.Remove(key)
+					delete(ref, key)
+				case 3:
+					// Increments even values and removes entries with odd values
+					value, keep := tree.Compute(key, func(oldValue int, exists bool) (int, bool) {
+						return oldValue + 1, !exists || oldValue%2 == 0
+					})
+					refValue, refExists := ref[key]
+					if keep != (!refExists || refValue%2 == 0) || value != refValue+1 {
+						t.Fatalf("compute %d returned %d, %t", key, value, keep)
+					}
+					if keep {
+						ref[key] = value
+					} else {
+						delete(ref, key)
+					}
+				case 4:
+					key, value, flag := tree.PollFirst()
+					checkPoll(t, ref, key, value, flag, false)
+				case 5:
+					key, value, flag := tree.PollLast()
+					checkPoll(t, ref, key, value, flag, true)
+				}
+				if idx%50 == 0 {
+					checkTreeMap(t, tree, ref)
+				}
+			}
+			checkTreeMap(t, tree, ref)
+			tree.Clear()
+			checkTreeMap(t, tree, map[int]int{})
+		})
+	}
+}
+
+func TestTreeMapSplitJoin(t *testing.T) {
+	tests := []struct {
+		name     string
+		slabSize int
+		count    int
+		splitKey int
+	}{
+		{"empty", 0, 0, 10},
+		{"before first", 0, 500, -1},
+		{"after last", 0, 500, 2000},
+		{"middle", 0, 500, 500},
+		{"pooled middle", 8, 500, 700},
+		{"single", 0, 1, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			random := rand.New(rand.NewSource(2))
+			tree, ref := randomTreeMap(random, test.slabSize, test.count, 1000)
+			lessRef := make(map[int]int)
+			greaterRef := make(map[int]int)
+			for key, value := range ref {
+				if key < test.splitKey {
+					lessRef[key] = value
+				} else {
+					greaterRef[key] = value
+				}
+			}
+			greaterTree := tree.SplitAt(test.splitKey)
+			checkTreeMap(t, tree, lessRef)
+			checkTreeMap(t, greaterTree, greaterRef)
+
+			// Both parts remain usable on their own
+			tree.Insert(test.splitKey-1, -1)
+			lessRef[test.splitKey-1] = -1
+			greaterTree.Insert(test.splitKey, -2)
+			greaterRef[test.splitKey] = -2
+			checkTreeMap(t, tree, lessRef)
+			checkTreeMap(t, greaterTree, greaterRef)
+
+			if _, err := JoinTreeMaps(greaterTree, tree); err != ErrKeyRangesOverlap {
+				t.Fatalf("joining overlapping maps returned %v", err)
+			}
+			checkTreeMap(t, tree, lessRef)
+			checkTreeMap(t, greaterTree, greaterRef)
+
+			joinedTree, err := JoinTreeMaps(tree, greaterTree)
+			if err != nil {
+				t.Fatal(err)
+			}
+			maps.Copy(lessRef, greaterRef)
+			checkTreeMap(t, joinedTree, lessRef)
+			checkTreeMap(t, tree, map[int]int{})
+			checkTreeMap(t, greaterTree, map[int]int{})
+		})
+	}
+}
+
+func TestTreeMapRemoveRange(t *testing.T) {
+	tests := []struct {
+		name  string
+		lower Bound[int]
+		upper Bound[int]
+	}{
+		{"all", Unbounded[int](), Unbounded[int]()},
+		{"inclusive", Inclusive(100), Inclusive(600)},
+		{"exclusive", Exclusive(100), Exclusive(600)},
+		{"below", Unbounded[int](), Exclusive(300)},
+		{"above", Inclusive(300), Unbounded[int]()},
+		{"empty", Inclusive(600), Inclusive(100)},
+		{"single", Inclusive(500), Inclusive(500)},
+		{"outside", Inclusive(2000), Unbounded[int]()},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			random := rand.New(rand.NewSource(3))
+			tree, ref := randomTreeMap(random, 0, 800, 1000)
+			tree.Insert(500, 500)
+			ref[500] = 500
+			expected := 0
+			for key := range ref {
+				if test.lower.admitsAbove(key, cmp.Compare[int]) && test.upper.admitsBelow(key, cmp.Compare[int]) {
+					delete(ref, key)
+					expected++
+				}
+			}
+			if count := tree.RemoveRange(test.lower, test.upper); count != expected {
+				t.Fatalf("removed %d entries, expected %d", count, expected)
+			}
+			checkTreeMap(t, tree, ref)
+		})
+	}
+}
+
+func TestTreeMapRemoveIf(t *testing.T) {
+	tests := []struct {
+		name      string
+		slabSize  int
+		predicate func(key, value int) bool
+	}{
+		{"none", 0, func(key, value int) bool { return false }},
+		{"all", 0, func(key, value int) bool { return true }},
+		{"even keys", 0, func(key, value int) bool { return key%2 == 0 }},
+		{"pooled odd values", 5, func(key, value int) bool { return value%2 == 1 }},
+		{"pooled most", 5, func(key, value int) bool { return key%10 != 0 }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			random := rand.New(rand.NewSource(4))
+			tree, ref := randomTreeMap(random, test.slabSize, 800, 1000)
+			expected := 0
+			for key, value := range ref {
+				if test.predicate(key, value) {
+					delete(ref, key)
+					expected++
+				}
+			}
+			if count := tree.RemoveIf(test.predicate); count != expected {
+				t.Fatalf("removed %d entries, expected %d", count, expected)
+			}
+			checkTreeMap(t, tree, ref)
+
+			// The remaining tree must still support updates
+			for idx := 0; idx < 200; idx++ {
+				key := random.Intn(1000)
+				tree.Insert(key, idx)
+				ref[key] = idx
+			}
+			checkTreeMap(t, tree, ref)
+		})
+	}
+}
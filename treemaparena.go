@@ -0,0 +1,189 @@
+// TreeMap node arena -- slab allocation and recycling of TreeMap nodes
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+// Allocator that hands out tree nodes from slabs of preallocated nodes and
+// recycles the nodes of removed entries
+type treeNodeArena[K, V any] struct {
+	slabs     [][]treeNode[K, V]
+	slabSize  int
+	usedSlabs int
+	// Next unused position in the last used slab
+	slabPos int
+	// Recycled nodes, linked by their greater pointers
+	freeList *treeNode[K, V]
+	// Set if nodes of the arena may be used by more than one map
+	shared bool
+}
+
+func newTreeNodeArena[K, V any](slabSize int) *treeNodeArena[K, V] {
+	if slabSize < 1 {
+		slabSize = 1
+	}
+	return &treeNodeArena[K, V]{nil, slabSize, 0, 0, nil, false}
+}
+
+func (arena *treeNodeArena[K, V]) allocNode(key K, value V, parent *treeNode[K, V]) *treeNode[K, V] {
+	var node *treeNode[K, V] = nil
+	if arena.freeList != nil {
+		node = arena.freeList
+		arena.freeList = node.greater
+	} else {
+		if arena.usedSlabs == 0 || arena.slabPos == arena.slabSize {
+			// Continue with the next slab
+			if arena.usedSlabs == len(arena.slabs) {
+				arena.slabs = append(arena.slabs, make([]treeNode[K, V], arena.slabSize))
+			}
+			arena.usedSlabs++
+			arena.slabPos = 0
+		}
+		node = &arena.slabs[arena.usedSlabs-1][arena.slabPos]
+		arena.slabPos++
+	}
+	*node = treeNode[K, V]{key, value, parent, nil, nil, 1, 1}
+	return node
+}
+
+func (arena *treeNodeArena[K, V]) freeNode(node *treeNode[K, V]) {
+	// Clear the node to release references to the key, the value and other nodes
+	*node = treeNode[K, V]{}
+	node.greater = arena.freeList
+	arena.freeList = node
+}
+
+// Recycles all nodes of the arena
+//
+// If clearNodes is set, the used nodes of the slabs are cleared to release
+// references to keys and values in O(n) time, otherwise the nodes keep their
+// keys and values reachable until they are reused, and the arena is reset
+// in O(1) time.
+func (arena *treeNodeArena[K, V]) reset(clearNodes bool) {
+	if clearNodes {
+		for idx := 0; idx < arena.usedSlabs; idx++ {
+			used := arena.slabSize
+			if idx == arena.usedSlabs-1 {
+				used = arena.slabPos
+			}
+			clear(arena.slabs[idx][:used])
+		}
+	}
+	arena.usedSlabs = 0
+	arena.slabPos = 0
+	arena.freeList = nil
+}
+
+// Creates a TreeMap with keys and values of type interface{} that allocates
+// its nodes from slabs of the specified number of nodes
+func NewPooledTreeMap(cmpFn compareFn[interface{}], slabSize int) *TreeMap[interface{}, interface{}] {
+	return NewPooledTreeMapOf[interface{}, interface{}](cmpFn, slabSize)
+}
+
+// Creates a TreeMap with keys of type K and values of type V that allocates
+// its nodes from slabs of the specified number of nodes
+//
+// Nodes of removed entries are recycled for new entries, and Clear recycles
+// all nodes without allocating. Reset recycles all nodes in O(1) time, but keeps
+// the old keys and values reachable until their nodes are reused. If entries
+// were moved to another map by SplitAt or JoinTreeMaps, the maps share the
+// slabs, and both Clear and Reset recycle the map's nodes one by one instead.
+func NewPooledTreeMapOf[K, V any](cmpFn compareFn[K], slabSize int) *TreeMap[K, V] {
+	tree := NewTreeMapOf[K, V](cmpFn)
+	tree.arena = newTreeNodeArena[K, V](slabSize)
+	return tree
+}
+
+// Removes all entries from the map
+//
+// The nodes of a pooled map are cleared, so that the map does not keep the
+// removed keys and values reachable.
+func (tree *TreeMap[K, V]) Clear() {
+	tree.removeAll(true)
+}
+
+// Removes all entries from the map in O(1) time if the map is pooled
+//
+// Unlike Clear, the nodes of a pooled map are not cleared and keep the removed
+// keys and values reachable until the nodes are reused for new entries, which
+// may never happen if the map stays smaller. Maps that are not pooled are
+// cleared like by Clear.
+func (tree *TreeMap[K, V]) Reset() {
+	tree.removeAll(false)
+}
+
+func (tree *TreeMap[K, V]) removeAll(clearNodes bool) {
+	if tree.arena != nil {
+		if tree.arena.shared {
+			tree.freeSubtree(tree.root)
+		} else {
+			tree.arena.reset(clearNodes)
+		}
+	}
+	tree.root = nil
+	tree.size = 0
+}
+
+func (tree *TreeMap[K, V]) allocNode(key K, value V, parent *treeNode[K, V]) *treeNode[K, V] {
+	var node *treeNode[K, V] = nil
+	if tree.arena != nil {
+		node = tree.arena.allocNode(key, value, parent)
+	} else {
+		node = newtreeNode(key, value, parent, nil, nil)
+	}
+	return node
+}
+
+// Recycles a node that has been unlinked from the tree
+func (tree *TreeMap[K, V]) freeNode(node *treeNode[K, V]) {
+	if tree.arena != nil {
+		tree.arena.freeNode(node)
+	}
+}
+
+// Recycles all nodes of a subtree that has been unlinked from the tree
+func (tree *TreeMap[K, V]) freeSubtree(node *treeNode[K, V]) {
+	if tree.arena != nil && node != nil {
+		tree.freeSubtree(node.less)
+		tree.freeSubtree(node.greater)
+		tree.arena.freeNode(node)
+	}
+}
+
+// Makes a map use the same arena as this map, which must be done when nodes
+// of this map are moved to the other map
+func (tree *TreeMap[K, V]) shareArena(other *TreeMap[K, V]) {
+	if tree.arena != nil {
+		tree.arena.shared = true
+		if other.arena == nil {
+			other.arena = tree.arena
+		} else {
+			other.arena.shared = true
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// TreeMap bulk construction -- linear time construction of a TreeMap from sorted entries
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
+)
+
+// Error that is reported if the input for the construction of a TreeMap
+// is not sorted in strictly ascending key order
+var ErrNotAscending = errors.New("dsaext: keys are not in strictly ascending order")
+
+// Creates a TreeMap from a slice of entries that is sorted in strictly
+// ascending key order according to the comparison function
+//
+// The tree is built in O(n) time without rebalancing.
+func NewTreeMapFromSorted[K, V any](cmpFn compareFn[K], entries []Entry[K, V]) (*TreeMap[K, V], error) {
+	nodes := make([]*treeNode[K, V], 0, len(entries))
+	var err error = nil
+	for idx, entry := range entries {
+		if idx > 0 && cmpFn(entries[idx-1].Key, entry.Key) >= 0 {
+			err = fmt.Errorf("%w: entry %d", ErrNotAscending, idx)
+			break
+		}
+		nodes = append(nodes, newtreeNode(entry.Key, entry.Value, nil, nil, nil))
+	}
+	var tree *TreeMap[K, V] = nil
+	if err == nil {
+		tree = newTreeMapFromNodes(cmpFn, nodes)
+	}
+	return tree, err
+}
+
+// Creates a TreeMap from a sequence of entries that is sorted in strictly
+// ascending key order according to the comparison function
+//
+// The tree is built in O(n) time without rebalancing.
+func NewTreeMapFromSortedSeq[K, V any](cmpFn compareFn[K], entries iter.Seq2[K, V]) (*TreeMap[K, V], error) {
+	var nodes []*treeNode[K, V]
+	var err error = nil
+	for key, value := range entries {
+		if len(nodes) > 0 && cmpFn(nodes[len(nodes)-1].key, key) >= 0 {
+			err = fmt.Errorf("%w: entry %d", ErrNotAscending, len(nodes))
+			break
+		}
+		nodes = append(nodes, newtreeNode(key, value, nil, nil, nil))
+	}
+	var tree *TreeMap[K, V] = nil
+	if err == nil {
+		tree = newTreeMapFromNodes(cmpFn, nodes)
+	}
+	return tree, err
+}
+
+// Creates a TreeMap from nodes that are sorted in strictly ascending key order
+func newTreeMapFromNodes[K, V any](cmpFn compareFn[K], nodes []*treeNode[K, V]) *TreeMap[K, V] {
+	tree := NewTreeMapOf[K, V](cmpFn)
+	tree.root = linkSortedNodes(nodes, nil)
+	tree.size = len(nodes)
+	return tree
+}
+
+// Links sorted nodes into a balanced tree and returns the tree's root
+//
+// The middle node becomes the root of each subtree, with the smaller half of the
+// remaining nodes on the less side. The level of a node is floor(log2(n + 1)) for a
+// subtree of n nodes, which satisfies the AA tree rules: the less child is always
+// one level lower, and the greater child is either one level lower or, if the
+// greater half is a perfect tree, on the same level with a lower greater child.
+func linkSortedNodes[K, V any](nodes []*treeNode[K, V], parent *treeNode[K, V]) *treeNode[K, V] {
+	var node *treeNode[K, V] = nil
+	if len(nodes) > 0 {
+		mid := (len(nodes) - 1) / 2
+		node = nodes[mid]
+		node.parent = parent
+		node.less = linkSortedNodes(nodes[:mid], node)
+		node.greater = linkSortedNodes(nodes[mid+1:], node)
+		node.level = bits.Len(uint(len(nodes)+1)) - 1
+		node.count = len(nodes)
+	}
+	return node
+}
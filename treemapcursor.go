@@ -0,0 +1,99 @@
+// TreeMapCursor -- bidirectional cursor over the entries of a TreeMap
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+// Cursor that can be positioned at any entry of a TreeMap and moved
+// towards lower or higher keys
+//
+// A cursor is not valid until it is positioned by one of the Seek methods.
+// Modifying the map invalidates the cursor.
+type TreeMapCursor[K, V any] struct {
+	tree *TreeMap[K, V]
+	node *treeNode[K, V]
+}
+
+func (tree *TreeMap[K, V]) Cursor() *TreeMapCursor[K, V] {
+	return &TreeMapCursor[K, V]{tree, nil}
+}
+
+func (cursor *TreeMapCursor[K, V]) Valid() bool {
+	return cursor.node != nil
+}
+
+// Positions the cursor at the entry with the lowest key
+func (cursor *TreeMapCursor[K, V]) SeekFirst() bool {
+	cursor.node = cursor.tree.firstNode()
+	return cursor.node != nil
+}
+
+// Positions the cursor at the entry with the highest key
+func (cursor *TreeMapCursor[K, V]) SeekLast() bool {
+	cursor.node = cursor.tree.lastNode()
+	return cursor.node != nil
+}
+
+// Positions the cursor at the entry with the smallest key greater than or
+// equal to the specified key
+func (cursor *TreeMapCursor[K, V]) Seek(key K) bool {
+	cursor.node = cursor.tree.ceilingNode(key, true)
+	return cursor.node != nil
+}
+
+// Moves the cursor to the entry with the next higher key
+func (cursor *TreeMapCursor[K, V]) Next() bool {
+	if cursor.node != nil {
+		cursor.node = cursor.node.successor()
+	}
+	return cursor.node != nil
+}
+
+// Moves the cursor to the entry with the next lower key
+func (cursor *TreeMapCursor[K, V]) Prev() bool {
+	if cursor.node != nil {
+		cursor.node = cursor.node.predecessor()
+	}
+	return cursor.node != nil
+}
+
+func (cursor *TreeMapCursor[K, V]) Key() K {
+	var key K
+	if cursor.node != nil {
+		key = cursor.node.key
+	}
+	return key
+}
+
+func (cursor *TreeMapCursor[K, V]) Value() V {
+	var value V
+	if cursor.node != nil {
+		value = cursor.node.value
+	}
+	return value
+}
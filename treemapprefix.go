@@ -0,0 +1,129 @@
+// TreeMap prefix scans -- iteration over string keys with a common prefix
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+import (
+	"errors"
+)
+
+// Error that is reported if a prefix scan of a map with interface{} keys is
+// requested with a prefix that is not a string
+var ErrPrefixNotString = errors.New("dsaext: prefix is not a string")
+
+// Returns an iterator over the entries with keys that start with the specified prefix
+//
+// The map's comparison function must order the keys bytewise, like CompareString.
+func PrefixIterator[K ~string, V any](tree *TreeMap[K, V], prefix K) *TreeMapIterator[K, V] {
+	lower, upper := prefixBounds(prefix)
+	return tree.Range(lower, upper)
+}
+
+// Returns the number of entries with keys that start with the specified prefix
+//
+// The same requirements as for PrefixIterator apply.
+func CountPrefix[K ~string, V any](tree *TreeMap[K, V], prefix K) int {
+	lower, upper := prefixBounds(prefix)
+	return tree.CountRange(lower, upper)
+}
+
+// Returns an iterator over the entries with keys that start with the specified prefix
+// in a map with interface{} keys of type string, such as a map that was created
+// with CompareString
+//
+// Returns ErrPrefixNotString if the prefix is not a string.
+func InterfacePrefixIterator[V any](tree *TreeMap[interface{}, V], prefix interface{}) (*TreeMapIterator[interface{}, V], error) {
+	var iter *TreeMapIterator[interface{}, V] = nil
+	lower, upper, err := interfacePrefixBounds(prefix)
+	if err == nil {
+		iter = tree.Range(lower, upper)
+	}
+	return iter, err
+}
+
+// Returns the number of entries with keys that start with the specified prefix
+// in a map with interface{} keys of type string
+//
+// The same requirements as for InterfacePrefixIterator apply.
+func InterfaceCountPrefix[V any](tree *TreeMap[interface{}, V], prefix interface{}) (int, error) {
+	count := 0
+	lower, upper, err := interfacePrefixBounds(prefix)
+	if err == nil {
+		count = tree.CountRange(lower, upper)
+	}
+	return count, err
+}
+
+// Returns the bounds of the range of strings that start with the specified prefix
+func prefixBounds[K ~string](prefix K) (Bound[K], Bound[K]) {
+	upper := Unbounded[K]()
+	upperText, bounded := prefixSuccessor(string(prefix))
+	if bounded {
+		upper = Exclusive(K(upperText))
+	}
+	return Inclusive(prefix), upper
+}
+
+func interfacePrefixBounds(prefix interface{}) (Bound[interface{}], Bound[interface{}], error) {
+	lower := Unbounded[interface{}]()
+	upper := Unbounded[interface{}]()
+	var err error = nil
+	text, isString := prefix.(string)
+	if isString {
+		lower = Inclusive[interface{}](text)
+		upperText, bounded := prefixSuccessor(text)
+		if bounded {
+			upper = Exclusive[interface{}](upperText)
+		}
+	} else {
+		err = ErrPrefixNotString
+	}
+	return lower, upper, err
+}
+
+// Returns the lowest string that is greater than all strings that start with the
+// specified prefix, and false if there is no such string
+//
+// The string is computed bytewise by removing all trailing 0xFF bytes and
+// incrementing the last remaining byte. The result is not necessarily valid
+// UTF-8, but UTF-8 strings are ordered the same way bytewise and by code points,
+// so it bounds the range of prefixed UTF-8 strings correctly.
+// Prefixes that are empty or consist only of 0xFF bytes have no such string.
+func prefixSuccessor(prefix string) (string, bool) {
+	text := []byte(prefix)
+	end := len(text)
+	for end > 0 && text[end-1] == 0xFF {
+		end--
+	}
+	bounded := end > 0
+	if bounded {
+		text[end-1]++
+	}
+	return string(text[:end]), bounded
+}
@@ -0,0 +1,153 @@
+// TreeMap rendering -- Graphviz DOT and ASCII rendering of the structure of a TreeMap
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Function that formats a key or a value for rendering
+type formatFn[T any] func(value T) string
+
+// Writes the structure of the tree in the Graphviz DOT language
+//
+// Each node is labeled with its key, value and level. Links to the less and the
+// greater child are labeled "<" and ">", and horizontal links, which connect
+// nodes on the same level, are drawn in bold red with both nodes on the same rank.
+// Keys and values are formatted by the format functions, or by fmt.Sprint if a
+// format function is nil.
+func (tree *TreeMap[K, V]) WriteDOT(writer io.Writer, keyFmt formatFn[K], valueFmt formatFn[V]) error {
+	keyFmt, valueFmt = defaultFormatFns(keyFmt, valueFmt)
+	var text strings.Builder
+	text.WriteString("digraph TreeMap {\n")
+	text.WriteString("\tnode [shape=box];\n")
+	if tree.root != nil {
+		nodeId := 0
+		tree.writeDOTNode(&text, tree.root, &nodeId, keyFmt, valueFmt)
+	}
+	text.WriteString("}\n")
+	_, err := io.WriteString(writer, text.String())
+	return err
+}
+
+// Writes the statements for the node and its subtrees and returns the node's identifier
+func (tree *TreeMap[K, V]) writeDOTNode(
+	text *strings.Builder,
+	node *treeNode[K, V],
+	nodeId *int,
+	keyFmt formatFn[K],
+	valueFmt formatFn[V],
+) int {
+	id := *nodeId
+	*nodeId++
+	label := keyFmt(node.key) + ": " + valueFmt(node.value) + "\n" + fmt.Sprintf("level %d", node.level)
+	fmt.Fprintf(text, "\tn%d [label=\"%s\"];\n", id, escapeDOT(label))
+	for _, child := range []*treeNode[K, V]{node.less, node.greater} {
+		if child != nil {
+			childId := tree.writeDOTNode(text, child, nodeId, keyFmt, valueFmt)
+			direction := "<"
+			if child == node.greater {
+				direction = ">"
+			}
+			if child.level == node.level {
+				fmt.Fprintf(text, "\tn%d -> n%d [label=\"%s\", style=bold, color=red];\n", id, childId, direction)
+				fmt.Fprintf(text, "\t{ rank=same; n%d; n%d; }\n", id, childId)
+			} else {
+				fmt.Fprintf(text, "\tn%d -> n%d [label=\"%s\"];\n", id, childId, direction)
+			}
+		}
+	}
+	return id
+}
+
+// Returns a multi-line ASCII rendering of the structure of the tree, e.g. for
+// messages of failed tests
+//
+// Each line shows a node's key, value and level, indented below its parent.
+// Less children are marked "<", greater children ">", horizontal links "(horizontal)",
+// and a missing child is shown as "(nil)" if its sibling exists.
+// Keys and values are formatted by the format functions, or by fmt.Sprint if a
+// format function is nil.
+func (tree *TreeMap[K, V]) DumpTree(keyFmt formatFn[K], valueFmt formatFn[V]) string {
+	keyFmt, valueFmt = defaultFormatFns(keyFmt, valueFmt)
+	var text strings.Builder
+	if tree.root != nil {
+		tree.dumpNode(&text, tree.root, "", "", keyFmt, valueFmt)
+	} else {
+		text.WriteString("(empty)\n")
+	}
+	return text.String()
+}
+
+func (tree *TreeMap[K, V]) dumpNode(
+	text *strings.Builder,
+	node *treeNode[K, V],
+	prefix string,
+	childPrefix string,
+	keyFmt formatFn[K],
+	valueFmt formatFn[V],
+) {
+	text.WriteString(prefix)
+	fmt.Fprintf(text, "%s: %s [%d]", keyFmt(node.key), valueFmt(node.value), node.level)
+	if node.parent != nil && node.parent.level == node.level {
+		text.WriteString(" (horizontal)")
+	}
+	text.WriteByte('\n')
+	if node.less != nil || node.greater != nil {
+		if node.less != nil {
+			tree.dumpNode(text, node.less, childPrefix+"+-< ", childPrefix+"|   ", keyFmt, valueFmt)
+		} else {
+			text.WriteString(childPrefix + "+-< (nil)\n")
+		}
+		if node.greater != nil {
+			tree.dumpNode(text, node.greater, childPrefix+"`-> ", childPrefix+"    ", keyFmt, valueFmt)
+		} else {
+			text.WriteString(childPrefix + "`-> (nil)\n")
+		}
+	}
+}
+
+func defaultFormatFns[K, V any](keyFmt formatFn[K], valueFmt formatFn[V]) (formatFn[K], formatFn[V]) {
+	if keyFmt == nil {
+		keyFmt = func(key K) string { return fmt.Sprint(key) }
+	}
+	if valueFmt == nil {
+		valueFmt = func(value V) string { return fmt.Sprint(value) }
+	}
+	return keyFmt, valueFmt
+}
+
+// Escapes text for use in a quoted string of the DOT language
+func escapeDOT(text string) string {
+	replacer := strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n")
+	return replacer.Replace(text)
+}
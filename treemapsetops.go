@@ -0,0 +1,139 @@
+// TreeMap set algebra -- union, intersection and difference of TreeMaps
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+import (
+	"errors"
+)
+
+// Error that is reported if maps that are combined do not use the same
+// comparison function, which is detected when the keys of the second map
+// are not in strictly ascending order under the first map's comparison function
+var ErrCompareFnMismatch = errors.New("dsaext: maps use different comparison functions")
+
+// Function that selects the value of the resulting entry if both maps
+// contain an entry with the same key
+type mergeFn[K, V any] func(key K, value1st, value2nd V) V
+
+// Selection of the entries that are copied to the resulting map
+const (
+	selectOnly1st = 1 << iota
+	selectOnly2nd
+	selectBoth
+)
+
+// Returns a new map that contains the entries of both maps
+//
+// If both maps contain the same key, the value of the resulting entry is
+// determined by the merge function, or taken from the first map if the
+// merge function is nil.
+func UnionTreeMaps[K, V any](map1st, map2nd *TreeMap[K, V], merge mergeFn[K, V]) (*TreeMap[K, V], error) {
+	return combineTreeMaps(map1st, map2nd, selectOnly1st|selectOnly2nd|selectBoth, merge)
+}
+
+// Returns a new map that contains the entries with keys that are contained in both maps
+//
+// The value of each resulting entry is determined by the merge function, or
+// taken from the first map if the merge function is nil.
+func IntersectTreeMaps[K, V any](map1st, map2nd *TreeMap[K, V], merge mergeFn[K, V]) (*TreeMap[K, V], error) {
+	return combineTreeMaps(map1st, map2nd, selectBoth, merge)
+}
+
+// Returns a new map that contains the entries of the first map with keys that
+// are not contained in the second map
+func DifferenceTreeMaps[K, V any](map1st, map2nd *TreeMap[K, V]) (*TreeMap[K, V], error) {
+	return combineTreeMaps(map1st, map2nd, selectOnly1st, nil)
+}
+
+// Returns a new map that contains the entries with keys that are contained
+// in exactly one of the maps
+func SymmetricDifferenceTreeMaps[K, V any](map1st, map2nd *TreeMap[K, V]) (*TreeMap[K, V], error) {
+	return combineTreeMaps(map1st, map2nd, selectOnly1st|selectOnly2nd, nil)
+}
+
+// Walks both maps in ascending key order and builds the resulting map
+// from the selected entries in O(n + m) time
+//
+// Comparison functions cannot be compared reliably, therefore the order of the
+// second map's keys is checked under the first map's comparison function while
+// walking, which guarantees that the resulting map is sorted.
+func combineTreeMaps[K, V any](map1st, map2nd *TreeMap[K, V], selection int, merge mergeFn[K, V]) (*TreeMap[K, V], error) {
+	var retTree *TreeMap[K, V] = nil
+	var err error = nil
+	var nodes []*treeNode[K, V]
+	node1st := map1st.firstNode()
+	node2nd := map2nd.firstNode()
+	for (node1st != nil || node2nd != nil) && err == nil {
+		var dir int
+		if node1st == nil {
+			dir = 1
+		} else if node2nd == nil {
+			dir = -1
+		} else {
+			dir = map1st.cmpFn(node1st.key, node2nd.key)
+		}
+		if dir < 0 {
+			if selection&selectOnly1st != 0 {
+				nodes = append(nodes, newtreeNode(node1st.key, node1st.value, nil, nil, nil))
+			}
+			node1st = node1st.successor()
+		} else if dir > 0 {
+			if selection&selectOnly2nd != 0 {
+				nodes = append(nodes, newtreeNode(node2nd.key, node2nd.value, nil, nil, nil))
+			}
+			node2nd, err = ascendingSuccessor(node2nd, map1st.cmpFn)
+		} else {
+			if selection&selectBoth != 0 {
+				value := node1st.value
+				if merge != nil {
+					value = merge(node1st.key, node1st.value, node2nd.value)
+				}
+				nodes = append(nodes, newtreeNode(node1st.key, value, nil, nil, nil))
+			}
+			node1st = node1st.successor()
+			node2nd, err = ascendingSuccessor(node2nd, map1st.cmpFn)
+		}
+	}
+	if err == nil {
+		retTree = newTreeMapFromNodes(map1st.cmpFn, nodes)
+	}
+	return retTree, err
+}
+
+// Returns the node's successor, or ErrCompareFnMismatch if the successor's key
+// is not greater than the node's key according to the comparison function
+func ascendingSuccessor[K, V any](node *treeNode[K, V], cmpFn compareFn[K]) (*treeNode[K, V], error) {
+	var err error = nil
+	next := node.successor()
+	if next != nil && cmpFn(node.key, next.key) >= 0 {
+		err = ErrCompareFnMismatch
+	}
+	return next, err
+}
@@ -0,0 +1,243 @@
+// TreeMap split and join -- partitioning and concatenation of TreeMaps
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+import (
+	"errors"
+)
+
+// Error that is reported if the keys of two maps that are joined overlap
+var ErrKeyRangesOverlap = errors.New("dsaext: key ranges of the maps overlap")
+
+// Removes all entries with keys greater than or equal to the specified key
+// from the map and returns those entries in a new map
+//
+// The tree is split in O(log n) time, no entries are copied. The new map has
+// the same settings, such as codecs, as this map.
+func (tree *TreeMap[K, V]) SplitAt(key K) *TreeMap[K, V] {
+	lessRoot, greaterRoot := tree.splitWalk(tree.root, key, true)
+
+	// skew and split set the tree's root when rotating the root of a detached
+	// subtree, therefore the roots of both trees are assigned explicitly
+	tree.root = lessRoot
+	tree.size = lessRoot.subtreeCount()
+
+	retTree := NewTreeMapOf[K, V](tree.cmpFn)
+	tree.shareArena(retTree)
+	retTree.augmentFn = tree.augmentFn
+	retTree.settings = tree.settings
+	retTree.root = greaterRoot
+	retTree.size = greaterRoot.subtreeCount()
+	return retTree
+}
+
+// Splits a detached subtree into a subtree with keys less than the specified key
+// and a subtree with keys greater than or equal to the specified key, or into
+// a subtree with keys less than or equal to the specified key and a subtree with
+// keys greater than the specified key if inclusive is not set
+func (tree *TreeMap[K, V]) splitWalk(node *treeNode[K, V], key K, inclusive bool) (*treeNode[K, V], *treeNode[K, V]) {
+	var lessRoot *treeNode[K, V] = nil
+	var greaterRoot *treeNode[K, V] = nil
+	if node != nil {
+		lessSub := node.less
+		greaterSub := node.greater
+		node.detach()
+		dir := tree.cmpFn(key, node.key)
+		if dir < 0 || (dir == 0 && inclusive) {
+			var midRoot *treeNode[K, V]
+			lessRoot, midRoot = tree.splitWalk(lessSub, key, inclusive)
+			greaterRoot = tree.joinNodes(midRoot, node, greaterSub)
+		} else {
+			var midRoot *treeNode[K, V]
+			midRoot, greaterRoot = tree.splitWalk(greaterSub, key, inclusive)
+			lessRoot = tree.joinNodes(lessSub, node, midRoot)
+		}
+	}
+	return lessRoot, greaterRoot
+}
+
+// Removes all entries from two maps and returns a new map that contains the
+// entries of both maps
+//
+// All keys in the left map must be less than all keys in the right map,
+// otherwise ErrKeyRangesOverlap is returned and both maps are left unchanged.
+// The maps are joined in O(log n) time, no entries are copied. The new map has
+// the settings, such as codecs, of the left map.
+func JoinTreeMaps[K, V any](left, right *TreeMap[K, V]) (*TreeMap[K, V], error) {
+	var retTree *TreeMap[K, V] = nil
+	var err error = nil
+	leftKey, leftFlag := left.GetLastKey()
+	rightKey, rightFlag := right.GetFirstKey()
+	if leftFlag && rightFlag && left.cmpFn(leftKey, rightKey) >= 0 {
+		err = ErrKeyRangesOverlap
+	} else {
+		retTree = NewTreeMapOf[K, V](left.cmpFn)
+		left.shareArena(retTree)
+		right.shareArena(retTree)
+		retTree.augmentFn = left.augmentFn
+		retTree.settings = left.settings
+		retTree.root = retTree.joinRoots(left.root, right.root)
+		retTree.size = left.size + right.size
+
+		left.root = nil
+		left.size = 0
+		right.root = nil
+		right.size = 0
+	}
+	return retTree, err
+}
+
+// Removes all entries with keys within the specified bounds and returns the
+// number of entries that were removed
+//
+// The entries are removed in O(log n) time by splitting the tree at both bounds
+// and joining the remaining subtrees.
+func (tree *TreeMap[K, V]) RemoveRange(lower, upper Bound[K]) int {
+	var lessRoot *treeNode[K, V] = nil
+	var greaterRoot *treeNode[K, V] = nil
+	rangeRoot := tree.root
+	if lower.kind != unboundedKind {
+		lessRoot, rangeRoot = tree.splitWalk(rangeRoot, lower.key, lower.kind == inclusiveKind)
+	}
+	if upper.kind != unboundedKind {
+		rangeRoot, greaterRoot = tree.splitWalk(rangeRoot, upper.key, upper.kind == exclusiveKind)
+	}
+	count := rangeRoot.subtreeCount()
+	tree.root = tree.joinRoots(lessRoot, greaterRoot)
+	tree.size -= count
+	tree.freeSubtree(rangeRoot)
+	return count
+}
+
+// Joins two detached subtrees, where all keys of the less subtree are less
+// than all keys of the greater subtree, and returns the root of the resulting subtree
+func (tree *TreeMap[K, V]) joinRoots(lessRoot, greaterRoot *treeNode[K, V]) *treeNode[K, V] {
+	retNode := lessRoot
+	if lessRoot == nil {
+		retNode = greaterRoot
+	} else if greaterRoot != nil {
+		// Split off the node with the lowest key of the greater subtree
+		// and use it to join the subtrees
+		minNode := greaterRoot
+		for minNode.less != nil {
+			minNode = minNode.less
+		}
+		midNode, restRoot := tree.splitWalk(greaterRoot, minNode.key, false)
+		retNode = tree.joinNodes(lessRoot, midNode, restRoot)
+	}
+	if retNode != nil {
+		retNode.parent = nil
+	}
+	return retNode
+}
+
+// Removes the links of a node to its parent and to its subtrees
+func (node *treeNode[K, V]) detach() {
+	if node.less != nil {
+		node.less.parent = nil
+	}
+	if node.greater != nil {
+		node.greater.parent = nil
+	}
+	node.parent = nil
+	node.less = nil
+	node.greater = nil
+}
+
+// Joins two detached subtrees and a detached node with a key between the keys
+// of the subtrees and returns the root of the resulting subtree
+func (tree *TreeMap[K, V]) joinNodes(lessRoot, midNode, greaterRoot *treeNode[K, V]) *treeNode[K, V] {
+	var retNode *treeNode[K, V] = nil
+	lessLevel := lessRoot.nodeLevel()
+	greaterLevel := greaterRoot.nodeLevel()
+	if lessLevel > greaterLevel {
+		retNode = tree.joinGreater(lessRoot, midNode, greaterRoot)
+	} else if lessLevel < greaterLevel {
+		retNode = tree.joinLess(lessRoot, midNode, greaterRoot)
+	} else {
+		retNode = tree.linkJoinNode(lessRoot, midNode, greaterRoot)
+	}
+	retNode.parent = nil
+	return retNode
+}
+
+// Attaches the mid node and the greater subtree to the greater spine of a
+// subtree with a higher level
+func (tree *TreeMap[K, V]) joinGreater(node, midNode, greaterRoot *treeNode[K, V]) *treeNode[K, V] {
+	retNode := node
+	if node.nodeLevel() <= greaterRoot.nodeLevel() {
+		retNode = tree.linkJoinNode(node, midNode, greaterRoot)
+	} else {
+		subNode := tree.joinGreater(node.greater, midNode, greaterRoot)
+		node.greater = subNode
+		subNode.parent = node
+		tree.updateNode(node)
+		// Rebalance the same way as after an insertion
+		retNode = tree.skew(retNode)
+		retNode = tree.split(retNode)
+	}
+	return retNode
+}
+
+// Attaches the less subtree and the mid node to the less spine of a
+// subtree with a higher level
+func (tree *TreeMap[K, V]) joinLess(lessRoot, midNode, node *treeNode[K, V]) *treeNode[K, V] {
+	retNode := node
+	if node.nodeLevel() <= lessRoot.nodeLevel() {
+		retNode = tree.linkJoinNode(lessRoot, midNode, node)
+	} else {
+		subNode := tree.joinLess(lessRoot, midNode, node.less)
+		node.less = subNode
+		subNode.parent = node
+		tree.updateNode(node)
+		// Rebalance the same way as after an insertion
+		retNode = tree.skew(retNode)
+		retNode = tree.split(retNode)
+	}
+	return retNode
+}
+
+// Makes the mid node the parent of two subtrees on the same level
+func (tree *TreeMap[K, V]) linkJoinNode(lessRoot, midNode, greaterRoot *treeNode[K, V]) *treeNode[K, V] {
+	midNode.less = lessRoot
+	midNode.greater = greaterRoot
+	if lessRoot != nil {
+		lessRoot.parent = midNode
+	}
+	if greaterRoot != nil {
+		greaterRoot.parent = midNode
+	}
+	midNode.level = lessRoot.nodeLevel() + 1
+	if greaterRoot.nodeLevel() >= midNode.level {
+		midNode.level = greaterRoot.nodeLevel() + 1
+	}
+	tree.updateNode(midNode)
+	return midNode
+}
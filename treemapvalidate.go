@@ -0,0 +1,129 @@
+// TreeMap validation -- structural invariant checks for TreeMaps
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error that is reported if a TreeMap's structure is corrupt
+var ErrTreeCorrupt = errors.New("dsaext: tree structure is corrupt")
+
+// Checks the structural invariants of the map's tree and returns an error
+// that describes the first violation that was found, or nil if the tree is valid
+//
+// The following invariants are checked:
+//   - The level of a node's less child is one less than the node's level
+//   - The level of a node's greater child is equal to or one less than the node's level
+//   - The level of a node's greater grandchild is less than the node's level
+//   - Leaf nodes are on level 1
+//   - The parent pointer of each node refers to the node that links to it
+//   - The subtree size of each node and the size of the map match the number of nodes
+//   - Keys are in strictly ascending order according to the comparison function
+func (tree *TreeMap[K, V]) Validate() error {
+	var err error = nil
+	if tree.root != nil && tree.root.parent != nil {
+		err = fmt.Errorf("%w: root node with key %v has a parent", ErrTreeCorrupt, tree.root.key)
+	}
+	if err == nil {
+		var count int
+		var lastNode *treeNode[K, V] = nil
+		count, err = tree.validateWalk(tree.root, &lastNode, 0)
+		if err == nil && count != tree.size {
+			err = fmt.Errorf("%w: size is %d, but the tree contains %d nodes", ErrTreeCorrupt, tree.size, count)
+		}
+	}
+	return err
+}
+
+// Validates a subtree in key order and returns the number of nodes in the subtree
+func (tree *TreeMap[K, V]) validateWalk(node *treeNode[K, V], lastNode **treeNode[K, V], depth int) (int, error) {
+	var count int = 0
+	var err error = nil
+	if node != nil {
+		if depth > tree.size {
+			// Either the size is wrong or the links contain a cycle
+			err = fmt.Errorf("%w: depth of node with key %v exceeds the size %d", ErrTreeCorrupt, node.key, tree.size)
+		}
+		if err == nil {
+			err = tree.validateNode(node)
+		}
+
+		var lessCount int
+		if err == nil {
+			lessCount, err = tree.validateWalk(node.less, lastNode, depth+1)
+		}
+		if err == nil {
+			if *lastNode != nil && tree.cmpFn((*lastNode).key, node.key) >= 0 {
+				err = fmt.Errorf("%w: key %v is not greater than the preceding key %v",
+					ErrTreeCorrupt, node.key, (*lastNode).key)
+			}
+			*lastNode = node
+		}
+		var greaterCount int
+		if err == nil {
+			greaterCount, err = tree.validateWalk(node.greater, lastNode, depth+1)
+		}
+
+		count = lessCount + 1 + greaterCount
+		if err == nil && node.count != count {
+			err = fmt.Errorf("%w: subtree size of node with key %v is %d, but the subtree contains %d nodes",
+				ErrTreeCorrupt, node.key, node.count, count)
+		}
+	}
+	return count, err
+}
+
+// Validates the level and the links of a single node
+func (tree *TreeMap[K, V]) validateNode(node *treeNode[K, V]) error {
+	var err error = nil
+	lessLevel := node.less.nodeLevel()
+	greaterLevel := node.greater.nodeLevel()
+	if node.less == nil && node.greater == nil && node.level != 1 {
+		err = fmt.Errorf("%w: leaf node with key %v is on level %d", ErrTreeCorrupt, node.key, node.level)
+	} else if lessLevel != node.level-1 {
+		err = fmt.Errorf("%w: node with key %v on level %d has a less child on level %d",
+			ErrTreeCorrupt, node.key, node.level, lessLevel)
+	} else if greaterLevel != node.level && greaterLevel != node.level-1 {
+		err = fmt.Errorf("%w: node with key %v on level %d has a greater child on level %d",
+			ErrTreeCorrupt, node.key, node.level, greaterLevel)
+	} else if node.greater != nil && node.greater.greater.nodeLevel() >= node.level {
+		err = fmt.Errorf("%w: node with key %v on level %d has two consecutive horizontal greater links",
+			ErrTreeCorrupt, node.key, node.level)
+	} else if node.less != nil && node.less.parent != node {
+		err = fmt.Errorf("%w: less child with key %v of node with key %v has a different parent",
+			ErrTreeCorrupt, node.less.key, node.key)
+	} else if node.greater != nil && node.greater.parent != node {
+		err = fmt.Errorf("%w: greater child with key %v of node with key %v has a different parent",
+			ErrTreeCorrupt, node.greater.key, node.key)
+	}
+	return err
+}
@@ -0,0 +1,160 @@
+// TreeMultiMap -- balanced binary search tree implementation of a key/value map with duplicate keys
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+import (
+	"iter"
+	"math"
+)
+
+// Key of an entry in a TreeMultiMap
+//
+// Entries with equal keys are ordered by the sequence number that is
+// assigned when the entry is inserted
+type multiKey[K any] struct {
+	key K
+	seq uint64
+}
+
+// Creates a comparison function for multiKeys from the comparison function for keys
+func multiKeyCompareFn[K any](cmpFn compareFn[K]) compareFn[multiKey[K]] {
+	return func(value1st, value2nd multiKey[K]) int {
+		result := cmpFn(value1st.key, value2nd.key)
+		if result == 0 {
+			if value1st.seq < value2nd.seq {
+				result = -1
+			} else if value1st.seq > value2nd.seq {
+				result = 1
+			}
+		}
+		return result
+	}
+}
+
+// Key/value map that can contain multiple entries with equal keys
+//
+// Entries with equal keys are kept in insertion order.
+type TreeMultiMap[K, V any] struct {
+	tree    *TreeMap[multiKey[K], V]
+	cmpFn   compareFn[K]
+	nextSeq uint64
+}
+
+type TreeMultiMapIterator[K, V any] struct {
+	mapIter *TreeMapIterator[multiKey[K], V]
+}
+
+func (iter *TreeMultiMapIterator[K, V]) Next() (K, V, bool) {
+	mKey, value, flag := iter.mapIter.Next()
+	return mKey.key, value, flag
+}
+
+// Creates a TreeMultiMap with keys and values of type interface{}
+func NewTreeMultiMap(cmpFn compareFn[interface{}]) *TreeMultiMap[interface{}, interface{}] {
+	return NewTreeMultiMapOf[interface{}, interface{}](cmpFn)
+}
+
+// Creates a TreeMultiMap with keys of type K and values of type V
+func NewTreeMultiMapOf[K, V any](cmpFn compareFn[K]) *TreeMultiMap[K, V] {
+	return &TreeMultiMap[K, V]{NewTreeMapOf[multiKey[K], V](multiKeyCompareFn(cmpFn)), cmpFn, 0}
+}
+
+// Returns an iterator over all entries in ascending key order, with entries
+// with equal keys in insertion order
+func (multiMap *TreeMultiMap[K, V]) Iterator() *TreeMultiMapIterator[K, V] {
+	return &TreeMultiMapIterator[K, V]{multiMap.tree.Iterator()}
+}
+
+// Returns a sequence of all entries in ascending key order, with entries
+// with equal keys in insertion order
+func (multiMap *TreeMultiMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for mKey, value := range multiMap.tree.All() {
+			if !yield(mKey.key, value) {
+				break
+			}
+		}
+	}
+}
+
+// Adds an entry after all existing entries with an equal key
+func (multiMap *TreeMultiMap[K, V]) Insert(key K, value V) {
+	multiMap.tree.Insert(multiKey[K]{key, multiMap.nextSeq}, value)
+	multiMap.nextSeq++
+}
+
+// Returns the values of all entries with the specified key in insertion order
+func (multiMap *TreeMultiMap[K, V]) GetAll(key K) []V {
+	var values []V
+	iter := multiMap.tree.Range(keyLowerBound(key), keyUpperBound(key))
+	for _, value, flag := iter.Next(); flag; _, value, flag = iter.Next() {
+		values = append(values, value)
+	}
+	return values
+}
+
+// Returns the number of entries with the specified key
+func (multiMap *TreeMultiMap[K, V]) Count(key K) int {
+	return multiMap.tree.CountRange(keyLowerBound(key), keyUpperBound(key))
+}
+
+// Removes the entry with the specified key that was inserted first
+//
+// The flag that is returned indicates whether an entry was removed.
+func (multiMap *TreeMultiMap[K, V]) RemoveOne(key K) bool {
+	node := multiMap.tree.lowerBoundNode(keyLowerBound(key))
+	retFlag := node != nil && multiMap.cmpFn(key, node.key.key) == 0
+	if retFlag {
+		multiMap.tree.removeNode(node)
+	}
+	return retFlag
+}
+
+// Removes all entries with the specified key and returns the number of
+// entries that were removed
+func (multiMap *TreeMultiMap[K, V]) RemoveAll(key K) int {
+	count := 0
+	for multiMap.RemoveOne(key) {
+		count++
+	}
+	return count
+}
+
+func (multiMap *TreeMultiMap[K, V]) GetSize() int {
+	return multiMap.tree.GetSize()
+}
+
+func keyLowerBound[K any](key K) Bound[multiKey[K]] {
+	return Inclusive(multiKey[K]{key, 0})
+}
+
+func keyUpperBound[K any](key K) Bound[multiKey[K]] {
+	return Inclusive(multiKey[K]{key, math.MaxUint64})
+}
@@ -0,0 +1,133 @@
+// TreeSet -- balanced binary search tree implementation of a sorted set
+//
+// @version 2026-10-18
+// @author  Robert Altnoeder (r.altnoeder@gmx.net)
+//
+// Copyright (C) 2026 Robert ALTNOEDER
+//
+// Redistribution and use in source and binary forms,
+// with or without modification, are permitted provided that
+// the following conditions are met:
+//
+//  1. Redistributions of source code must retain the above copyright notice,
+//     this list of conditions and the following disclaimer.
+//  2. Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the distribution.
+//  3. The name of the author may not be used to endorse or promote products
+//     derived from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE AUTHOR ``AS IS'' AND ANY EXPRESS OR
+// IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES
+// OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED.
+// IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE,
+// EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package dsaext
+
+import (
+	"iter"
+)
+
+// Sorted set of keys
+//
+// The set is a TreeMap with values of the zero size type struct{}
+type TreeSet[K any] struct {
+	tree *TreeMap[K, struct{}]
+}
+
+type TreeSetIterator[K any] struct {
+	mapIter *TreeMapIterator[K, struct{}]
+}
+
+func (iter *TreeSetIterator[K]) Next() (K, bool) {
+	key, _, flag := iter.mapIter.Next()
+	return key, flag
+}
+
+// Creates a TreeSet with keys of type interface{}
+func NewTreeSet(cmpFn compareFn[interface{}]) *TreeSet[interface{}] {
+	return NewTreeSetOf[interface{}](cmpFn)
+}
+
+// Creates a TreeSet with keys of type K
+func NewTreeSetOf[K any](cmpFn compareFn[K]) *TreeSet[K] {
+	return &TreeSet[K]{NewTreeMapOf[K, struct{}](cmpFn)}
+}
+
+func (set *TreeSet[K]) Add(key K) {
+	set.tree.Insert(key, struct{}{})
+}
+
+func (set *TreeSet[K]) Contains(key K) bool {
+	_, flag := set.tree.Get(key)
+	return flag
+}
+
+func (set *TreeSet[K]) Remove(key K) {
+	set.tree.Remove(key)
+}
+
+func (set *TreeSet[K]) First() (K, bool) {
+	return set.tree.GetFirstKey()
+}
+
+func (set *TreeSet[K]) Last() (K, bool) {
+	return set.tree.GetLastKey()
+}
+
+func (set *TreeSet[K]) GetSize() int {
+	return set.tree.GetSize()
+}
+
+func (set *TreeSet[K]) Iterator() *TreeSetIterator[K] {
+	return &TreeSetIterator[K]{set.tree.Iterator()}
+}
+
+// Returns an iterator over the keys within the specified bounds
+func (set *TreeSet[K]) Range(lower, upper Bound[K]) *TreeSetIterator[K] {
+	return &TreeSetIterator[K]{set.tree.Range(lower, upper)}
+}
+
+// Returns a sequence of all keys in ascending order
+func (set *TreeSet[K]) All() iter.Seq[K] {
+	return set.tree.Keys()
+}
+
+// Indicates whether all keys of this set are contained in the other set
+func (set *TreeSet[K]) IsSubset(other *TreeSet[K]) bool {
+	result := set.GetSize() <= other.GetSize()
+	if result {
+		for key := range set.All() {
+			if !other.Contains(key) {
+				result = false
+				break
+			}
+		}
+	}
+	return result
+}
+
+// Returns a new set that contains the keys of both sets
+func (set *TreeSet[K]) Union(other *TreeSet[K]) (*TreeSet[K], error) {
+	var retSet *TreeSet[K] = nil
+	tree, err := UnionTreeMaps(set.tree, other.tree, nil)
+	if err == nil {
+		retSet = &TreeSet[K]{tree}
+	}
+	return retSet, err
+}
+
+// Returns a new set that contains the keys that are contained in both sets
+func (set *TreeSet[K]) Intersect(other *TreeSet[K]) (*TreeSet[K], error) {
+	var retSet *TreeSet[K] = nil
+	tree, err := IntersectTreeMaps(set.tree, other.tree, nil)
+	if err == nil {
+		retSet = &TreeSet[K]{tree}
+	}
+	return retSet, err
+}
@@ -29,31 +29,36 @@
 // EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
 package dsaext
 
-type vMapNode struct {
-	key   interface{}
-	value interface{}
-	prev  *vMapNode
-	next  *vMapNode
+import (
+	"iter"
+)
+
+type vMapNode[K, V any] struct {
+	key   K
+	value V
+	prev  *vMapNode[K, V]
+	next  *vMapNode[K, V]
 }
 
-func newVMapNode(key, value interface{}, prev, next *vMapNode) *vMapNode {
-	return &vMapNode{key, value, prev, next}
+func newVMapNode[K, V any](key K, value V, prev, next *vMapNode[K, V]) *vMapNode[K, V] {
+	return &vMapNode[K, V]{key, value, prev, next}
 }
 
-type VMap struct {
-	head  *vMapNode
-	tail  *vMapNode
-	size  int
-	cmpFn compareFn
+type VMap[K, V any] struct {
+	head     *vMapNode[K, V]
+	tail     *vMapNode[K, V]
+	size     int
+	cmpFn    compareFn[K]
+	settings mapSettings[K, V]
 }
 
-type VMapIterator struct {
-	nextNode *vMapNode
+type VMapIterator[K, V any] struct {
+	nextNode *vMapNode[K, V]
 }
 
-func (iter *VMapIterator) Next() (interface{}, interface{}, bool) {
-	var key interface{} = nil
-	var value interface{} = nil
+func (iter *VMapIterator[K, V]) Next() (K, V, bool) {
+	var key K
+	var value V
 	retFlag := false
 	if iter.nextNode != nil {
 		key = iter.nextNode.key
@@ -64,25 +69,75 @@ func (iter *VMapIterator) Next() (interface{}, interface{}, bool) {
 	return key, value, retFlag
 }
 
-func NewVMap(cmpFn compareFn) *VMap {
-	return &VMap{nil, nil, 0, cmpFn}
+// Creates a VMap with keys and values of type interface{}
+func NewVMap(cmpFn compareFn[interface{}]) *VMap[interface{}, interface{}] {
+	return NewVMapOf[interface{}, interface{}](cmpFn)
+}
+
+// Creates a VMap with keys of type K and values of type V
+func NewVMapOf[K, V any](cmpFn compareFn[K]) *VMap[K, V] {
+	return &VMap[K, V]{nil, nil, 0, cmpFn, mapSettings[K, V]{}}
+}
+
+func (mapObj *VMap[K, V]) Iterator() *VMapIterator[K, V] {
+	return &VMapIterator[K, V]{mapObj.head}
 }
 
-func (mapObj *VMap) Iterator() *VMapIterator {
-	return &VMapIterator{mapObj.head}
+// Returns a sequence of all entries in the order of the map
+func (mapObj *VMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := mapObj.head; node != nil; node = node.next {
+			if !yield(node.key, node.value) {
+				break
+			}
+		}
+	}
+}
+
+// Returns a sequence of all entries in reverse order
+func (mapObj *VMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := mapObj.tail; node != nil; node = node.prev {
+			if !yield(node.key, node.value) {
+				break
+			}
+		}
+	}
+}
+
+// Returns a sequence of all keys in the order of the map
+func (mapObj *VMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for node := mapObj.head; node != nil; node = node.next {
+			if !yield(node.key) {
+				break
+			}
+		}
+	}
+}
+
+// Returns a sequence of all values in the order of the map
+func (mapObj *VMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for node := mapObj.head; node != nil; node = node.next {
+			if !yield(node.value) {
+				break
+			}
+		}
+	}
 }
 
-func (mapObj *VMap) Clear() {
+func (mapObj *VMap[K, V]) Clear() {
 	mapObj.head = nil
 	mapObj.tail = nil
 	mapObj.size = 0
 }
 
-func (mapObj *VMap) GetSize() int {
+func (mapObj *VMap[K, V]) GetSize() int {
 	return mapObj.size
 }
 
-func (mapObj *VMap) Prepend(key, value interface{}) {
+func (mapObj *VMap[K, V]) Prepend(key K, value V) {
 	node := newVMapNode(key, value, nil, mapObj.head)
 	if mapObj.head != nil {
 		mapObj.head.prev = node
@@ -94,7 +149,7 @@ func (mapObj *VMap) Prepend(key, value interface{}) {
 	mapObj.size++
 }
 
-func (mapObj *VMap) Append(key, value interface{}) {
+func (mapObj *VMap[K, V]) Append(key K, value V) {
 	node := newVMapNode(key, value, mapObj.tail, nil)
 	if mapObj.tail != nil {
 		mapObj.tail.next = node
@@ -106,8 +161,8 @@ func (mapObj *VMap) Append(key, value interface{}) {
 	mapObj.size++
 }
 
-func (mapObj *VMap) Get(key interface{}) (interface{}, bool) {
-	var retValue interface{} = nil
+func (mapObj *VMap[K, V]) Get(key K) (V, bool) {
+	var retValue V
 	node, retFlag := mapObj.findNode(key)
 	if retFlag {
 		retValue = node.value
@@ -115,9 +170,9 @@ func (mapObj *VMap) Get(key interface{}) (interface{}, bool) {
 	return retValue, retFlag
 }
 
-func (mapObj *VMap) GetFirst() (interface{}, interface{}, bool) {
-	var retKey interface{} = nil
-	var retValue interface{} = nil
+func (mapObj *VMap[K, V]) GetFirst() (K, V, bool) {
+	var retKey K
+	var retValue V
 	retFlag := false
 	if mapObj.head != nil {
 		retKey = mapObj.head.key
@@ -127,9 +182,9 @@ func (mapObj *VMap) GetFirst() (interface{}, interface{}, bool) {
 	return retKey, retValue, retFlag
 }
 
-func (mapObj *VMap) GetLast() (interface{}, interface{}, bool) {
-	var retKey interface{} = nil
-	var retValue interface{} = nil
+func (mapObj *VMap[K, V]) GetLast() (K, V, bool) {
+	var retKey K
+	var retValue V
 	retFlag := false
 	if mapObj.tail != nil {
 		retKey = mapObj.tail.key
@@ -139,7 +194,7 @@ func (mapObj *VMap) GetLast() (interface{}, interface{}, bool) {
 	return retKey, retValue, retFlag
 }
 
-func (mapObj *VMap) Remove(key interface{}) {
+func (mapObj *VMap[K, V]) Remove(key K) {
 	node, found := mapObj.findNode(key)
 	if found {
 		if mapObj.head == node {
@@ -156,7 +211,7 @@ func (mapObj *VMap) Remove(key interface{}) {
 	}
 }
 
-func (mapObj *VMap) findNode(key interface{}) (*vMapNode, bool) {
+func (mapObj *VMap[K, V]) findNode(key K) (*vMapNode[K, V], bool) {
 	node := mapObj.head
 	for node != nil {
 		if mapObj.cmpFn(key, node.key) == 0 {